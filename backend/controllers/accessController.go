@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func RequestAccess(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var ownerID int
+	var isPrivate bool
+	err = globals.DB.QueryRowContext(ctx, "SELECT id, is_private FROM users WHERE username = ?", username).Scan(&ownerID, &isPrivate)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	if ownerID == userID {
+		http.Error(w, "You cannot request access to your own profile", http.StatusBadRequest)
+		return
+	}
+
+	if blocked, err := services.IsBlocked(ctx, ownerID, userID); err != nil || blocked {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	if !isPrivate {
+		http.Error(w, "This account is public", http.StatusBadRequest)
+		return
+	}
+
+	result, err := globals.DB.ExecContext(ctx, "INSERT IGNORE INTO profile_access_requests (owner_id, requester_id) VALUES (?, ?)", ownerID, userID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("RequestAccess: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		if err := services.NotifyUser(ctx, ownerID, userID, models.EmailTypeAccessRequested, 0); err != nil {
+			log.Printf("RequestAccess: notification error: %v", err)
+		}
+	}
+
+	status, err := profileAccessStatus(ctx, ownerID, userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Erişim isteği gönderildi",
+		"data": map[string]interface{}{
+			"user_id":       ownerID,
+			"username":      username,
+			"access_status": status,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func CancelAccessRequest(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var ownerID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE username = ?", username).Scan(&ownerID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	_, err = globals.DB.ExecContext(ctx, "DELETE FROM profile_access_requests WHERE owner_id = ? AND requester_id = ?", ownerID, userID)
+	if err != nil {
+		log.Printf("CancelAccessRequest: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Erişim isteği kaldırıldı",
+		"data": map[string]interface{}{
+			"user_id":       ownerID,
+			"username":      username,
+			"access_status": accessStatusNone,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetAccessRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = accessStatusPending
+	}
+	if status != accessStatusPending && status != accessStatusApproved {
+		http.Error(w, "Status must be pending or approved", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseCursorParams(r, defaultPageSize, sortNewest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM profile_access_requests WHERE owner_id = ? AND status = ?"
+	err = globals.DB.QueryRowContext(ctx, countQuery, userID, status).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("ar", "")
+
+	query := `
+		SELECT
+			ar.id,
+			u.id,
+			u.username,
+			ar.status,
+			ar.created_at,
+			ar.responded_at
+		FROM profile_access_requests ar
+		JOIN users u ON ar.requester_id = u.id
+		WHERE ar.owner_id = ? AND ar.status = ? AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append([]interface{}{userID, status}, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var requests []models.AccessRequestDTO
+	var cursors []listCursor
+	for rows.Next() {
+		var request models.AccessRequestDTO
+		if err := rows.Scan(&request.ID, &request.UserID, &request.Username, &request.Status, &request.CreatedAt, &request.RespondedAt); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		requests = append(requests, request)
+		cursors = append(cursors, listCursor{CreatedAt: request.CreatedAt, ID: request.ID})
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	requests, pagination := cursorPage(requests, cursors, params, reversed, total)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"requests":   requests,
+			"status":     status,
+			"pagination": pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func ApproveAccessRequest(w http.ResponseWriter, r *http.Request) {
+	respondAccessRequest(w, r,
+		"UPDATE profile_access_requests SET status = 'approved', responded_at = NOW() WHERE id = ? AND owner_id = ? AND status = 'pending'",
+		"Erişim isteği onaylandı")
+}
+
+func DenyAccessRequest(w http.ResponseWriter, r *http.Request) {
+	respondAccessRequest(w, r,
+		"DELETE FROM profile_access_requests WHERE id = ? AND owner_id = ? AND status = 'pending'",
+		"Erişim isteği reddedildi")
+}
+
+func RevokeAccess(w http.ResponseWriter, r *http.Request) {
+	respondAccessRequest(w, r,
+		"DELETE FROM profile_access_requests WHERE id = ? AND owner_id = ?",
+		"Erişim kaldırıldı")
+}
+
+func respondAccessRequest(w http.ResponseWriter, r *http.Request, query string, message string) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	requestIDstr := r.PathValue("request_id")
+	requestID, err := strconv.Atoi(requestIDstr)
+	if err != nil {
+		http.Error(w, "Invalid request ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var requesterID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT requester_id FROM profile_access_requests WHERE id = ? AND owner_id = ?", requestID, userID).Scan(&requesterID)
+	if err != nil {
+		http.Error(w, "Access request not found", http.StatusNotFound)
+		return
+	}
+
+	result, err := globals.DB.ExecContext(ctx, query, requestID, userID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("respondAccessRequest: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		http.Error(w, "Access request is not pending", http.StatusConflict)
+		return
+	}
+
+	status, err := profileAccessStatus(ctx, userID, requesterID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if status == accessStatusApproved {
+		if err := services.NotifyUser(ctx, requesterID, userID, models.EmailTypeAccessApproved, 0); err != nil {
+			log.Printf("respondAccessRequest: notification error: %v", err)
+		}
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"request_id":    requestID,
+			"user_id":       requesterID,
+			"access_status": status,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func ArchivePost(w http.ResponseWriter, r *http.Request) {
+	setPostArchived(w, r, true)
+}
+
+func UnarchivePost(w http.ResponseWriter, r *http.Request) {
+	setPostArchived(w, r, false)
+}
+
+func setPostArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	checkQuery := "SELECT user_id, archived_at IS NOT NULL FROM posts WHERE id = ? AND deleted_at IS NULL"
+	var postOwnerID int
+	var isArchived bool
+	err = globals.DB.QueryRowContext(ctx, checkQuery, postID).Scan(&postOwnerID, &isArchived)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if postOwnerID != userID {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	var query string
+	var message string
+	if archived {
+		query = "UPDATE posts SET archived_at = NOW(), pinned_at = NULL WHERE id = ? AND user_id = ? AND archived_at IS NULL"
+		message = "Gönderi arşivlendi"
+	} else {
+		query = "UPDATE posts SET archived_at = NULL WHERE id = ? AND user_id = ? AND archived_at IS NOT NULL"
+		message = "Gönderi arşivden çıkarıldı"
+	}
+
+	if isArchived != archived {
+		tx, err := globals.DB.BeginTx(ctx, nil)
+		if err != nil {
+			http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback()
+
+		exec, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+			return
+		}
+		defer exec.Close()
+
+		if _, err := exec.ExecContext(ctx, postID, userID); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				http.Error(w, "Timeout", http.StatusInternalServerError)
+				return
+			}
+			log.Printf("setPostArchived: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if archived {
+			if err := removeReposts(ctx, tx, postID); err != nil {
+				log.Printf("setPostArchived: repost cleanup error: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Printf("setPostArchived: commit error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"post_id":     postID,
+			"user_id":     userID,
+			"is_archived": archived,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
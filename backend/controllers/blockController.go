@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+func BlockUser(w http.ResponseWriter, r *http.Request) {
+	setUserRelation(w, r, "user_blocks", "blocker_id", "blocked_id", true, "Kullanıcı engellendi")
+}
+
+func UnblockUser(w http.ResponseWriter, r *http.Request) {
+	setUserRelation(w, r, "user_blocks", "blocker_id", "blocked_id", false, "Kullanıcının engeli kaldırıldı")
+}
+
+func MuteUser(w http.ResponseWriter, r *http.Request) {
+	setUserRelation(w, r, "user_mutes", "muter_id", "muted_id", true, "Kullanıcı sessize alındı")
+}
+
+func UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	setUserRelation(w, r, "user_mutes", "muter_id", "muted_id", false, "Kullanıcının sesi açıldı")
+}
+
+func setUserRelation(w http.ResponseWriter, r *http.Request, table string, ownerColumn string, targetColumn string, active bool, message string) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var targetUserID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE username = ?", username).Scan(&targetUserID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	if targetUserID == userID {
+		http.Error(w, "You cannot do this to yourself", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	var query string
+	if active {
+		query = "INSERT IGNORE INTO " + table + " (" + ownerColumn + ", " + targetColumn + ") VALUES (?, ?)"
+	} else {
+		query = "DELETE FROM " + table + " WHERE " + ownerColumn + " = ? AND " + targetColumn + " = ?"
+	}
+
+	if _, err := tx.ExecContext(ctx, query, userID, targetUserID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("setUserRelation: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if active && table == "user_blocks" {
+		cleanupQueries := []string{
+			"DELETE FROM follows WHERE (follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			"DELETE FROM profile_access_requests WHERE (owner_id = ? AND requester_id = ?) OR (owner_id = ? AND requester_id = ?)",
+		}
+		for _, cleanupQuery := range cleanupQueries {
+			if _, err := tx.ExecContext(ctx, cleanupQuery, userID, targetUserID, targetUserID, userID); err != nil {
+				log.Printf("setUserRelation: db error: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"user_id":  targetUserID,
+			"username": username,
+			"active":   active,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	listUserRelations(w, r, "user_blocks", "blocker_id", "blocked_id")
+}
+
+func GetMutedUsers(w http.ResponseWriter, r *http.Request) {
+	listUserRelations(w, r, "user_mutes", "muter_id", "muted_id")
+}
+
+func listUserRelations(w http.ResponseWriter, r *http.Request, table string, ownerColumn string, targetColumn string) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	params, err := parseCursorParams(r, defaultPageSize, sortNewest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var total int
+	err = globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE "+ownerColumn+" = ?", userID).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("rel", "")
+
+	query := `
+		SELECT rel.id, u.id, u.username, rel.created_at
+		FROM ` + table + ` rel
+		JOIN users u ON rel.` + targetColumn + ` = u.id
+		WHERE rel.` + ownerColumn + ` = ? AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append([]interface{}{userID}, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var users []models.UserRelationDTO
+	var cursors []listCursor
+	for rows.Next() {
+		var user models.UserRelationDTO
+		var relationID int
+		if err := rows.Scan(&relationID, &user.UserID, &user.Username, &user.CreatedAt); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		users = append(users, user)
+		cursors = append(cursors, listCursor{CreatedAt: user.CreatedAt, ID: relationID})
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	users, pagination := cursorPage(users, cursors, params, reversed, total)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"users":      users,
+			"pagination": pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,726 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxChallengeTitle       = 100
+	maxChallengeDescription = 500
+	maxChallengeStickers    = 8
+)
+
+const challengeStatusColumn = `
+	CASE
+		WHEN c.closed_at IS NOT NULL OR c.ends_at <= NOW() THEN 'closed'
+		WHEN c.starts_at > NOW() THEN 'upcoming'
+		ELSE 'active'
+	END
+`
+
+const challengeColumns = `
+	c.id,
+	c.title,
+	c.description,
+	c.starts_at,
+	c.ends_at,
+	` + challengeStatusColumn + ` as status,
+	(SELECT COUNT(*) FROM challenge_entries WHERE challenge_id = c.id) as entry_count,
+	EXISTS(SELECT 1 FROM challenge_entries WHERE challenge_id = c.id AND user_id = ?) as has_entered,
+	c.winner_post_id,
+	c.winner_user_id,
+	wu.username,
+	c.closed_at,
+	c.created_at
+`
+
+func scanChallenge(scanner interface{ Scan(...interface{}) error }) (models.ChallengeDTO, error) {
+	var challenge models.ChallengeDTO
+	err := scanner.Scan(
+		&challenge.ID,
+		&challenge.Title,
+		&challenge.Description,
+		&challenge.StartsAt,
+		&challenge.EndsAt,
+		&challenge.Status,
+		&challenge.EntryCount,
+		&challenge.HasEntered,
+		&challenge.WinnerPostID,
+		&challenge.WinnerUserID,
+		&challenge.WinnerUsername,
+		&challenge.ClosedAt,
+		&challenge.CreatedAt,
+	)
+	return challenge, err
+}
+
+func attachChallengeStickers(ctx context.Context, challenges []models.ChallengeDTO) error {
+	if len(challenges) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(challenges))
+	for i, challenge := range challenges {
+		ids[i] = challenge.ID
+		challenges[i].Stickers = []string{}
+	}
+
+	placeholders, args := inClause(ids)
+	query := "SELECT challenge_id, filter_name FROM challenge_stickers WHERE challenge_id IN (" + placeholders + ") ORDER BY filter_name"
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stickers := make(map[int][]string)
+	for rows.Next() {
+		var challengeID int
+		var filterName string
+		if err := rows.Scan(&challengeID, &filterName); err != nil {
+			return err
+		}
+		stickers[challengeID] = append(stickers[challengeID], filterName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range challenges {
+		if list, ok := stickers[challenges[i].ID]; ok {
+			challenges[i].Stickers = list
+		}
+	}
+	return nil
+}
+
+func parseChallengeTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(value))
+}
+
+func CreateChallenge(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" || len(req.Title) > maxChallengeTitle {
+		http.Error(w, "Invalid title", http.StatusBadRequest)
+		return
+	}
+
+	req.Description = strings.TrimSpace(req.Description)
+	if len(req.Description) > maxChallengeDescription {
+		http.Error(w, "Too long description", http.StatusBadRequest)
+		return
+	}
+
+	var description *string
+	if req.Description != "" {
+		description = &req.Description
+	}
+
+	if len(req.Stickers) > maxChallengeStickers {
+		http.Error(w, "Too many stickers", http.StatusBadRequest)
+		return
+	}
+
+	stickers := make([]string, 0, len(req.Stickers))
+	seen := make(map[string]bool)
+	for _, sticker := range req.Stickers {
+		if !services.IsValidFilter(sticker) {
+			http.Error(w, "Invalid sticker", http.StatusBadRequest)
+			return
+		}
+		if !seen[sticker] {
+			seen[sticker] = true
+			stickers = append(stickers, sticker)
+		}
+	}
+
+	startsAt, err := parseChallengeTime(req.StartsAt)
+	if err != nil {
+		http.Error(w, "Invalid starts_at", http.StatusBadRequest)
+		return
+	}
+
+	endsAt, err := parseChallengeTime(req.EndsAt)
+	if err != nil {
+		http.Error(w, "Invalid ends_at", http.StatusBadRequest)
+		return
+	}
+
+	if !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		http.Error(w, "Challenge must end after it starts and in the future", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if !isModerator(ctx, userID) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO challenges (title, description, created_by, starts_at, ends_at) VALUES (?, ?, ?, ?, ?)"
+	result, err := tx.ExecContext(ctx, query, req.Title, description, userID, startsAt.UTC().Format(time.DateTime), endsAt.UTC().Format(time.DateTime))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("CreateChallenge: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	challengeID, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	for _, sticker := range stickers {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO challenge_stickers (challenge_id, filter_name) VALUES (?, ?)", challengeID, sticker); err != nil {
+			log.Printf("CreateChallenge: sticker insert error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	challenge, err := loadChallenge(ctx, int(challengeID), userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Yarışma oluşturuldu",
+		"data":    challenge,
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBytes)
+}
+
+func loadChallenge(ctx context.Context, challengeID int, viewerID int) (models.ChallengeDTO, error) {
+	query := "SELECT " + challengeColumns + " FROM challenges c LEFT JOIN users wu ON wu.id = c.winner_user_id WHERE c.id = ?"
+	challenge, err := scanChallenge(globals.DB.QueryRowContext(ctx, query, viewerID, challengeID))
+	if err != nil {
+		return challenge, err
+	}
+
+	challenges := []models.ChallengeDTO{challenge}
+	if err := attachChallengeStickers(ctx, challenges); err != nil {
+		return challenge, err
+	}
+	return challenges[0], nil
+}
+
+func GetChallenges(w http.ResponseWriter, r *http.Request) {
+	viewerID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	var filter, order string
+	switch status {
+	case "":
+		filter = "1 = 1"
+		order = "c.starts_at DESC, c.id DESC"
+	case "active":
+		filter = "c.closed_at IS NULL AND c.starts_at <= NOW() AND c.ends_at > NOW()"
+		order = "c.ends_at ASC, c.id ASC"
+	case "upcoming":
+		filter = "c.closed_at IS NULL AND c.starts_at > NOW()"
+		order = "c.starts_at ASC, c.id ASC"
+	case "closed":
+		filter = "(c.closed_at IS NOT NULL OR c.ends_at <= NOW())"
+		order = "c.ends_at DESC, c.id DESC"
+	default:
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, offset := parsePagination(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var total int
+	err = globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM challenges c WHERE "+filter).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := "SELECT " + challengeColumns + `
+		FROM challenges c
+		LEFT JOIN users wu ON wu.id = c.winner_user_id
+		WHERE ` + filter + `
+		ORDER BY ` + order + `
+		LIMIT ? OFFSET ?
+	`
+	rows, err := globals.DB.QueryContext(ctx, query, viewerID, limit, offset)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	challenges := []models.ChallengeDTO{}
+	for rows.Next() {
+		challenge, err := scanChallenge(rows)
+		if err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		challenges = append(challenges, challenge)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachChallengeStickers(ctx, challenges); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"challenges": challenges,
+			"pagination": newPaginationInfo(page, limit, total),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetChallenge(w http.ResponseWriter, r *http.Request) {
+	viewerID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	challengeID, err := strconv.Atoi(r.PathValue("challenge_id"))
+	if err != nil {
+		http.Error(w, "Invalid challenge ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	challenge, err := loadChallenge(ctx, challengeID, viewerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Challenge not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data":    challenge,
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func lookupActiveChallenge(ctx context.Context, challengeID int) (string, string, error) {
+	query := "SELECT starts_at, " + challengeStatusColumn + " FROM challenges c WHERE c.id = ?"
+	var startsAt, status string
+	err := globals.DB.QueryRowContext(ctx, query, challengeID).Scan(&startsAt, &status)
+	return startsAt, status, err
+}
+
+func SubmitChallengeEntry(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	challengeID, err := strconv.Atoi(r.PathValue("challenge_id"))
+	if err != nil {
+		http.Error(w, "Invalid challenge ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SubmitChallengeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PostID <= 0 {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	startsAt, status, err := lookupActiveChallenge(ctx, challengeID)
+	if err != nil {
+		http.Error(w, "Challenge not found", http.StatusNotFound)
+		return
+	}
+
+	if status != "active" {
+		http.Error(w, "Challenge is not accepting entries", http.StatusConflict)
+		return
+	}
+
+	var isPrivate bool
+	err = globals.DB.QueryRowContext(ctx, "SELECT is_private FROM users WHERE id = ?", userID).Scan(&isPrivate)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if isPrivate {
+		http.Error(w, "Private accounts cannot enter challenges", http.StatusForbidden)
+		return
+	}
+
+	postQuery := "SELECT p.created_at >= ? FROM posts p WHERE p.id = ? AND p.user_id = ? AND " + publicPostFilter
+	var createdInWindow bool
+	err = globals.DB.QueryRowContext(ctx, postQuery, startsAt, req.PostID, userID).Scan(&createdInWindow)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if !createdInWindow {
+		http.Error(w, "Post must be created during the challenge", http.StatusBadRequest)
+		return
+	}
+
+	stickerQuery := `
+		SELECT NOT EXISTS(SELECT 1 FROM challenge_stickers WHERE challenge_id = ?)
+			OR EXISTS(
+				SELECT 1 FROM post_media pm
+				JOIN challenge_stickers cs ON cs.filter_name = pm.filter_name
+				WHERE pm.post_id = ? AND cs.challenge_id = ?
+			)
+	`
+	var usesSticker bool
+	err = globals.DB.QueryRowContext(ctx, stickerQuery, challengeID, req.PostID, challengeID).Scan(&usesSticker)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if !usesSticker {
+		http.Error(w, "Post must use one of the challenge stickers", http.StatusBadRequest)
+		return
+	}
+
+	query := "INSERT IGNORE INTO challenge_entries (challenge_id, post_id, user_id) VALUES (?, ?, ?)"
+	result, err := globals.DB.ExecContext(ctx, query, challengeID, req.PostID, userID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SubmitChallengeEntry: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		http.Error(w, "You already entered this challenge", http.StatusConflict)
+		return
+	}
+
+	entryID, _ := result.LastInsertId()
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Gönderi yarışmaya katıldı",
+		"data": map[string]interface{}{
+			"entry_id":     entryID,
+			"challenge_id": challengeID,
+			"post_id":      req.PostID,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBytes)
+}
+
+func WithdrawChallengeEntry(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	challengeID, err := strconv.Atoi(r.PathValue("challenge_id"))
+	if err != nil {
+		http.Error(w, "Invalid challenge ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	_, status, err := lookupActiveChallenge(ctx, challengeID)
+	if err != nil {
+		http.Error(w, "Challenge not found", http.StatusNotFound)
+		return
+	}
+
+	if status != "active" {
+		http.Error(w, "Entries can only be withdrawn while the challenge is active", http.StatusConflict)
+		return
+	}
+
+	result, err := globals.DB.ExecContext(ctx, "DELETE FROM challenge_entries WHERE challenge_id = ? AND user_id = ?", challengeID, userID)
+	if err != nil {
+		log.Printf("WithdrawChallengeEntry: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		http.Error(w, "Entry not found", http.StatusNotFound)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Yarışma katılımı geri çekildi",
+		"data": map[string]interface{}{
+			"challenge_id": challengeID,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetChallengeEntries(w http.ResponseWriter, r *http.Request) {
+	viewerID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	challengeID, err := strconv.Atoi(r.PathValue("challenge_id"))
+	if err != nil {
+		http.Error(w, "Invalid challenge ID", http.StatusBadRequest)
+		return
+	}
+
+	var order string
+	switch r.URL.Query().Get("sort") {
+	case "", "top":
+		order = "vote_count DESC, ce.created_at ASC, ce.id ASC"
+	case "newest":
+		order = "ce.created_at DESC, ce.id DESC"
+	default:
+		http.Error(w, "Invalid sort", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, offset := parsePagination(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err = globals.DB.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM challenges WHERE id = ?)", challengeID).Scan(&exists)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		http.Error(w, "Challenge not found", http.StatusNotFound)
+		return
+	}
+
+	visibleFilter, visibleArgs := viewablePostFilter(viewerID)
+	filter := "ce.challenge_id = ? AND " + visibleFilter
+	filterArgs := append([]interface{}{challengeID}, visibleArgs...)
+
+	var totalEntries int
+	countQuery := "SELECT COUNT(*) FROM challenge_entries ce JOIN posts p ON p.id = ce.post_id WHERE " + filter
+	err = globals.DB.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&totalEntries)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := `
+		SELECT
+			p.id,
+			p.user_id,
+			u.username,
+			p.image_path,
+			p.like_count,
+			p.comment_count,
+			(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+			EXISTS(SELECT 1 FROM posts_likes WHERE post_id = p.id AND user_id = ?) as is_liked,
+			EXISTS(SELECT 1 FROM posts_saves WHERE post_id = p.id AND user_id = ?) as is_saved,
+			p.created_at,
+			ce.id,
+			IF(c.closed_at IS NULL, ` + services.ChallengeVoteCount("ce", "c") + `, ce.vote_count) as vote_count,
+			c.winner_post_id <=> p.id as is_winner
+		FROM challenge_entries ce
+		JOIN challenges c ON c.id = ce.challenge_id
+		JOIN posts p ON p.id = ce.post_id
+		JOIN users u ON p.user_id = u.id
+		WHERE ` + filter + `
+		ORDER BY ` + order + `
+		LIMIT ? OFFSET ?
+	`
+	args := append([]interface{}{viewerID, viewerID}, filterArgs...)
+	args = append(args, limit, offset)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var posts []models.FeedPostDTO
+	var entries []models.ChallengeEntryDTO
+	for rows.Next() {
+		var entry models.ChallengeEntryDTO
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.Username,
+			&entry.ImagePath,
+			&entry.LikeCount,
+			&entry.CommentCount,
+			&entry.RepostCount,
+			&entry.IsLiked,
+			&entry.IsSaved,
+			&entry.CreatedAt,
+			&entry.EntryID,
+			&entry.VoteCount,
+			&entry.IsWinner,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		posts = append(posts, entry.FeedPostDTO)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedReactions(ctx, posts, viewerID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	for i := range entries {
+		entries[i].FeedPostDTO = posts[i]
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"entries":    entries,
+			"pagination": newPaginationInfo(page, limit, totalEntries),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,629 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maxCommentDepth = 2
+
+func ReplyComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parentIDstr := r.PathValue("comment_id")
+	parentID, err := strconv.Atoi(parentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var reply models.CreateReply
+	if err := json.NewDecoder(r.Body).Decode(&reply); err != nil {
+		http.Error(w, "Bad Input", http.StatusBadRequest)
+		return
+	}
+
+	if len(reply.Comment) >= 255 {
+		http.Error(w, "Too long comment", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	parentQuery := "SELECT post_id, user_id, depth, is_hidden FROM posts_comments WHERE id = ?"
+	var postID int
+	var parentAuthorID int
+	var parentDepth int
+	var parentHidden bool
+	err = globals.DB.QueryRowContext(ctx, parentQuery, parentID).Scan(&postID, &parentAuthorID, &parentDepth, &parentHidden)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if blocked, err := services.IsBlocked(ctx, parentAuthorID, userID); err != nil || blocked {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	postOwnerID, err := lookupVisiblePost(ctx, postID, userID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if parentHidden && userID != parentAuthorID && userID != postOwnerID {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if err := checkCommentPolicy(ctx, postID, postOwnerID, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if parentDepth >= maxCommentDepth {
+		http.Error(w, "Replies cannot be nested any deeper", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	insertQuery := "INSERT INTO posts_comments (user_id, post_id, parent_id, depth, comment) VALUES (?, ?, ?, ?, ?)"
+	exec, err := tx.PrepareContext(ctx, insertQuery)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	result, err := exec.ExecContext(ctx, userID, postID, parentID, parentDepth+1, reply.Comment)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("ReplyComment: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	commentID, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error getting commentID", http.StatusInternalServerError)
+		return
+	}
+
+	if err := refreshPostCommentCount(ctx, tx, postID); err != nil {
+		log.Printf("ReplyComment: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	mentions, mentioned, err := storeCommentMentions(ctx, tx, int(commentID), reply.Comment)
+	if err != nil {
+		log.Printf("ReplyComment: mention error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ReplyComment: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := services.NotifyUser(ctx, parentAuthorID, userID, models.EmailTypeCommentReplied, postID); err != nil {
+		log.Printf("ReplyComment: notification error: %v", err)
+	}
+
+	notifyCommentMentions(ctx, postID, postOwnerID, userID, mentioned)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Yanıt eklendi",
+		"data": map[string]interface{}{
+			"comment_id": commentID,
+			"parent_id":  parentID,
+			"post_id":    postID,
+			"user_id":    userID,
+			"mentions":   mentions,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBytes)
+}
+
+func GetCommentReplies(w http.ResponseWriter, r *http.Request) {
+	viewerID, _ := services.GetUserIDFromRequest(r)
+
+	parentIDstr := r.PathValue("comment_id")
+	parentID, err := strconv.Atoi(parentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseCursorParams(r, defaultCommentPageSize, sortOldest, sortOldest, sortNewest, sortTop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var postID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT post_id FROM posts_comments WHERE id = ?", parentID).Scan(&postID)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	postOwnerID, err := lookupVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	hiddenFilter, hiddenArgs := visibleCommentFilter("c", postOwnerID, viewerID)
+
+	scope := "c.parent_id = ? AND " + hiddenFilter
+	scopeArgs := append([]interface{}{parentID}, hiddenArgs...)
+
+	replies, pagination, err := loadCommentPage(ctx, scope, scopeArgs, params, postOwnerID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"comments":    replies,
+			"count":       len(replies),
+			"total_count": pagination.TotalCount,
+			"pagination":  pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func EditComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	commentIDstr := r.PathValue("comment_id")
+	commentID, err := strconv.Atoi(commentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var edit models.EditComment
+	if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+		http.Error(w, "Bad Input", http.StatusBadRequest)
+		return
+	}
+
+	if len(edit.Comment) == 0 {
+		http.Error(w, "Comment cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	if len(edit.Comment) >= 255 {
+		http.Error(w, "Too long comment", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	checkQuery := "SELECT user_id, post_id, comment, created_at >= DATE_SUB(NOW(), INTERVAL ? SECOND) FROM posts_comments WHERE id = ? FOR UPDATE"
+	var commentOwnerID int
+	var postID int
+	var previous string
+	var withinWindow bool
+	err = tx.QueryRowContext(ctx, checkQuery, int(services.CommentEditWindow().Seconds()), commentID).Scan(&commentOwnerID, &postID, &previous, &withinWindow)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if commentOwnerID != userID {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	if !withinWindow {
+		http.Error(w, "Edit window has expired", http.StatusForbidden)
+		return
+	}
+
+	if previous != edit.Comment {
+		revisionQuery := "INSERT INTO posts_comments_revisions (comment_id, comment, edited_by) VALUES (?, ?, ?)"
+		if _, err := tx.ExecContext(ctx, revisionQuery, commentID, previous, userID); err != nil {
+			log.Printf("EditComment: revision error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		updateQuery := "UPDATE posts_comments SET comment = ?, edited_at = NOW() WHERE id = ? AND user_id = ?"
+		if _, err := tx.ExecContext(ctx, updateQuery, edit.Comment, commentID, userID); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				http.Error(w, "Timeout", http.StatusInternalServerError)
+				return
+			}
+			log.Printf("EditComment: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var postOwnerID int
+	if err := tx.QueryRowContext(ctx, "SELECT user_id FROM posts WHERE id = ?", postID).Scan(&postOwnerID); err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	mentions, mentioned, err := storeCommentMentions(ctx, tx, commentID, edit.Comment)
+	if err != nil {
+		log.Printf("EditComment: mention error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var editedAt *string
+	if err := tx.QueryRowContext(ctx, "SELECT edited_at FROM posts_comments WHERE id = ?", commentID).Scan(&editedAt); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("EditComment: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	notifyCommentMentions(ctx, postID, postOwnerID, userID, mentioned)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Yorum düzenlendi",
+		"data": map[string]interface{}{
+			"comment_id": commentID,
+			"comment":    edit.Comment,
+			"edited_at":  editedAt,
+			"mentions":   mentions,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	commentIDstr := r.PathValue("comment_id")
+	commentID, err := strconv.Atoi(commentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if !isModerator(ctx, userID) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	var current string
+	err = globals.DB.QueryRowContext(ctx, "SELECT comment FROM posts_comments WHERE id = ?", commentID).Scan(&current)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	query := `
+		SELECT rv.id, rv.comment, rv.edited_by, u.username, rv.created_at
+		FROM posts_comments_revisions rv
+		JOIN users u ON rv.edited_by = u.id
+		WHERE rv.comment_id = ?
+		ORDER BY rv.created_at ASC, rv.id ASC
+	`
+	rows, err := globals.DB.QueryContext(ctx, query, commentID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var revisions []models.CommentRevisionDTO
+	for rows.Next() {
+		var revision models.CommentRevisionDTO
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.Comment,
+			&revision.EditedBy,
+			&revision.Username,
+			&revision.CreatedAt,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"comment_id": commentID,
+			"current":    current,
+			"revisions":  revisions,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+const defaultCommentPageSize = 20
+
+func loadCommentPage(ctx context.Context, scope string, scopeArgs []interface{}, params cursorParams, postOwnerID int, viewerID int) ([]models.PostCommentsDTO, models.CursorInfo, error) {
+	var total int
+	countQuery := "SELECT COUNT(*) FROM posts_comments c WHERE " + scope
+	if err := globals.DB.QueryRowContext(ctx, countQuery, scopeArgs...).Scan(&total); err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("c", "c.like_count")
+	replyFilter, replyArgs := visibleCommentFilter("rc", postOwnerID, viewerID)
+
+	query := `
+		SELECT
+			c.id,
+			c.user_id,
+			u.username,
+			c.comment,
+			c.parent_id,
+			(SELECT COUNT(*) FROM posts_comments rc WHERE rc.parent_id = c.id AND ` + replyFilter + `) as reply_count,
+			c.created_at,
+			c.edited_at,
+			c.is_hidden,
+			c.like_count,
+			EXISTS(SELECT 1 FROM comments_likes WHERE comment_id = c.id AND user_id = ?) as is_liked
+		FROM posts_comments c
+		JOIN users u ON c.user_id = u.id
+		WHERE ` + scope + ` AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append(replyArgs, viewerID)
+	args = append(args, scopeArgs...)
+	args = append(args, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+	defer rows.Close()
+
+	var comments []models.PostCommentsDTO
+	var cursors []listCursor
+	for rows.Next() {
+		var comment models.PostCommentsDTO
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.UserID,
+			&comment.Username,
+			&comment.Comment,
+			&comment.ParentID,
+			&comment.ReplyCount,
+			&comment.CreatedAt,
+			&comment.EditedAt,
+			&comment.IsHidden,
+			&comment.LikeCount,
+			&comment.IsLiked,
+		); err != nil {
+			return nil, models.CursorInfo{}, err
+		}
+		comments = append(comments, comment)
+		cursors = append(cursors, listCursor{CreatedAt: comment.CreatedAt, ID: comment.ID, Score: comment.LikeCount})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	comments, pagination := cursorPage(comments, cursors, params, reversed, total)
+
+	if err := attachCommentMentions(ctx, comments); err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	return comments, pagination, nil
+}
+
+func LikeComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	commentIDstr := r.PathValue("comment_id")
+	commentID, err := strconv.Atoi(commentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	commentQuery := "SELECT post_id, user_id, is_hidden FROM posts_comments WHERE id = ?"
+	var postID int
+	var authorID int
+	var isHidden bool
+	err = globals.DB.QueryRowContext(ctx, commentQuery, commentID).Scan(&postID, &authorID, &isHidden)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if blocked, err := services.IsBlocked(ctx, authorID, userID); err != nil || blocked {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	postOwnerID, err := lookupVisiblePost(ctx, postID, userID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if isHidden && userID != authorID && userID != postOwnerID {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT IGNORE INTO comments_likes (user_id, comment_id) VALUES (?, ?)", userID, commentID)
+	if err != nil {
+		log.Printf("LikeComment: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	action := "liked"
+	delta := 1
+	if inserted, _ := result.RowsAffected(); inserted == 0 {
+		action = "unliked"
+		delta = -1
+		if _, err := tx.ExecContext(ctx, "DELETE FROM comments_likes WHERE user_id = ? AND comment_id = ?", userID, commentID); err != nil {
+			log.Printf("LikeComment: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE posts_comments SET like_count = GREATEST(CAST(like_count AS SIGNED) + ?, 0) WHERE id = ?", delta, commentID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("LikeComment: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var likeCount int
+	if err := tx.QueryRowContext(ctx, "SELECT like_count FROM posts_comments WHERE id = ?", commentID).Scan(&likeCount); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("LikeComment: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"comment_id": commentID,
+			"user_id":    userID,
+			"action":     action,
+			"like_count": likeCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"camagru/models"
+	"camagru/services"
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	sortMostLiked     = "most_liked"
+	sortMostCommented = "most_commented"
+	sortTrending      = "trending"
+)
+
+const maxFeedUsernames = 20
+
+var feedSorts = []string{sortNewest, sortOldest, sortMostLiked, sortMostCommented, sortTrending}
+
+var errLikedFilterUnauthorized = errors.New("liked filter requires authentication")
+
+type feedFilter struct {
+	Usernames []string
+	From      *time.Time
+	To        *time.Time
+	Liked     *bool
+	Sort      string
+}
+
+func parseFeedFilter(r *http.Request, viewerID int) (feedFilter, error) {
+	query := r.URL.Query()
+	filter := feedFilter{Sort: sortNewest}
+
+	if usernames := query.Get("usernames"); usernames != "" {
+		for _, username := range strings.Split(usernames, ",") {
+			username = strings.TrimSpace(username)
+			if username == "" || slices.Contains(filter.Usernames, username) {
+				continue
+			}
+			if len(username) > 30 || !usernameRegex.MatchString(username) {
+				return filter, fmt.Errorf("invalid username %q", username)
+			}
+			filter.Usernames = append(filter.Usernames, username)
+		}
+		if len(filter.Usernames) > maxFeedUsernames {
+			return filter, fmt.Errorf("at most %d usernames can be filtered", maxFeedUsernames)
+		}
+	}
+
+	var err error
+	if filter.From, err = parseFeedDate(query.Get("from"), false); err != nil {
+		return filter, errors.New("from must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+	}
+	if filter.To, err = parseFeedDate(query.Get("to"), true); err != nil {
+		return filter, errors.New("to must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, errors.New("from must be before to")
+	}
+
+	switch liked := query.Get("liked"); liked {
+	case "":
+	case "true", "false":
+		if viewerID == 0 {
+			return filter, errLikedFilterUnauthorized
+		}
+		value := liked == "true"
+		filter.Liked = &value
+	default:
+		return filter, errors.New("liked must be true or false")
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		if !slices.Contains(feedSorts, sort) {
+			return filter, fmt.Errorf("sort must be one of %s", strings.Join(feedSorts, ", "))
+		}
+		filter.Sort = sort
+	}
+
+	return filter, nil
+}
+
+func parseFeedDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return &t, nil
+}
+
+func (f feedFilter) visibility(viewerID int) ([]string, []interface{}) {
+	visibleFilter, args := viewablePostFilter(viewerID)
+	conditions := []string{visibleFilter}
+
+	if viewerID != 0 {
+		conditions = append(conditions, "NOT EXISTS(SELECT 1 FROM user_mutes um WHERE um.muter_id = ? AND um.muted_id = p.user_id)")
+		args = append(args, viewerID)
+	}
+
+	if f.Sort == sortTrending {
+		conditions = append(conditions, "p.created_at >= DATE_SUB(NOW(), INTERVAL ? DAY)")
+		args = append(args, services.TrendingWindowDays)
+	}
+
+	return conditions, args
+}
+
+func (f feedFilter) itemConditions(usernameColumn string, createdAtColumn string, viewerID int) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(f.Usernames) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(f.Usernames)), ",")
+		conditions = append(conditions, usernameColumn+" IN ("+placeholders+")")
+		for _, username := range f.Usernames {
+			args = append(args, username)
+		}
+	}
+
+	if f.From != nil {
+		conditions = append(conditions, createdAtColumn+" >= ?")
+		args = append(args, f.From.Format(time.DateTime))
+	}
+	if f.To != nil {
+		conditions = append(conditions, createdAtColumn+" <= ?")
+		args = append(args, f.To.Format(time.DateTime))
+	}
+
+	if f.Liked != nil {
+		likedCondition := "EXISTS(SELECT 1 FROM posts_likes WHERE user_id = ? AND post_id = p.id)"
+		if !*f.Liked {
+			likedCondition = "NOT " + likedCondition
+		}
+		conditions = append(conditions, likedCondition)
+		args = append(args, viewerID)
+	}
+
+	return conditions, args
+}
+
+func (f feedFilter) where(viewerID int) (string, []interface{}) {
+	conditions, args := f.visibility(viewerID)
+
+	itemConditions, itemArgs := f.itemConditions("u.username", "p.created_at", viewerID)
+	conditions = append(conditions, itemConditions...)
+	args = append(args, itemArgs...)
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (f feedFilter) repostWhere(viewerID int) (string, []interface{}) {
+	conditions, args := f.visibility(viewerID)
+
+	blockFilter, blockArgs := notBlockedFilter("r.user_id", viewerID)
+	conditions = append(conditions, blockFilter)
+	args = append(args, blockArgs...)
+
+	if viewerID != 0 {
+		conditions = append(conditions, "NOT EXISTS(SELECT 1 FROM user_mutes um WHERE um.muter_id = ? AND um.muted_id = r.user_id)")
+		args = append(args, viewerID)
+	}
+
+	itemConditions, itemArgs := f.itemConditions("ru.username", "r.created_at", viewerID)
+	conditions = append(conditions, itemConditions...)
+	args = append(args, itemArgs...)
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (f feedFilter) includesReposts() bool {
+	return f.Sort == sortNewest || f.Sort == sortOldest
+}
+
+func (f feedFilter) from() string {
+	from := "FROM posts p JOIN users u ON p.user_id = u.id"
+	if f.Sort == sortTrending {
+		from += " LEFT JOIN post_scores ps ON ps.post_id = p.id"
+	}
+	return from
+}
+
+func (f feedFilter) repostFrom() string {
+	return `
+		FROM reposts r
+		JOIN posts p ON p.id = r.post_id
+		JOIN users u ON p.user_id = u.id
+		JOIN users ru ON ru.id = r.user_id
+	`
+}
+
+func (f feedFilter) orderBy() string {
+	switch f.Sort {
+	case sortTrending:
+		return "COALESCE(ps.score, 0) DESC, p.id DESC"
+	case sortOldest:
+		return "p.created_at ASC, p.id ASC"
+	case sortMostLiked:
+		return "p.like_count DESC, p.created_at DESC, p.id DESC"
+	case sortMostCommented:
+		return "p.comment_count DESC, p.created_at DESC, p.id DESC"
+	default:
+		return "p.created_at DESC, p.id DESC"
+	}
+}
+
+func feedItemCursor(post models.FeedPostDTO) listCursor {
+	if post.Repost != nil {
+		return listCursor{CreatedAt: post.Repost.CreatedAt, ID: post.Repost.ID, Repost: true}
+	}
+	return listCursor{CreatedAt: post.CreatedAt, ID: post.ID}
+}
+
+func compareFeedCursors(a listCursor, b listCursor) int {
+	return cmp.Or(
+		strings.Compare(a.CreatedAt, b.CreatedAt),
+		cmp.Compare(boolRank(a.Repost), boolRank(b.Repost)),
+		cmp.Compare(a.ID, b.ID),
+	)
+}
+
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func mergeFeedItems(posts []models.FeedPostDTO, reposts []models.FeedPostDTO, ascending bool) ([]models.FeedPostDTO, []listCursor) {
+	items := slices.Concat(posts, reposts)
+	slices.SortFunc(items, func(a, b models.FeedPostDTO) int {
+		order := compareFeedCursors(feedItemCursor(a), feedItemCursor(b))
+		if ascending {
+			return order
+		}
+		return -order
+	})
+
+	cursors := make([]listCursor, len(items))
+	for i, item := range items {
+		cursors[i] = feedItemCursor(item)
+	}
+	return items, cursors
+}
@@ -0,0 +1,294 @@
+package controllers
+
+import (
+	"camagru/models"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseFeedFilter(t *testing.T) {
+	date := func(value string) *time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &parsed
+	}
+	liked := true
+	notLiked := false
+
+	tests := []struct {
+		name     string
+		query    string
+		viewerID int
+		wantErr  bool
+		want     feedFilter
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  feedFilter{Sort: sortNewest},
+		},
+		{
+			name:  "usernames are trimmed and deduplicated",
+			query: "?usernames=alice,%20bob,,alice",
+			want:  feedFilter{Usernames: []string{"alice", "bob"}, Sort: sortNewest},
+		},
+		{
+			name:    "invalid username",
+			query:   "?usernames=al!ce",
+			wantErr: true,
+		},
+		{
+			name:    "too many usernames",
+			query:   "?usernames=u01,u02,u03,u04,u05,u06,u07,u08,u09,u10,u11,u12,u13,u14,u15,u16,u17,u18,u19,u20,u21",
+			wantErr: true,
+		},
+		{
+			name:  "dates cover whole days",
+			query: "?from=2026-01-01&to=2026-01-31",
+			want: feedFilter{
+				From: date("2026-01-01T00:00:00Z"),
+				To:   date("2026-01-31T23:59:59Z"),
+				Sort: sortNewest,
+			},
+		},
+		{
+			name:  "timestamps are converted to UTC",
+			query: "?from=2026-01-01T03:00:00%2B03:00",
+			want:  feedFilter{From: date("2026-01-01T00:00:00Z"), Sort: sortNewest},
+		},
+		{
+			name:    "malformed date",
+			query:   "?from=01/02/2026",
+			wantErr: true,
+		},
+		{
+			name:    "from after to",
+			query:   "?from=2026-02-01&to=2026-01-01",
+			wantErr: true,
+		},
+		{
+			name:     "liked",
+			query:    "?liked=true",
+			viewerID: 1,
+			want:     feedFilter{Liked: &liked, Sort: sortNewest},
+		},
+		{
+			name:     "not liked",
+			query:    "?liked=false",
+			viewerID: 1,
+			want:     feedFilter{Liked: &notLiked, Sort: sortNewest},
+		},
+		{
+			name:    "liked requires a viewer",
+			query:   "?liked=true",
+			wantErr: true,
+		},
+		{
+			name:     "liked must be a boolean",
+			query:    "?liked=yes",
+			viewerID: 1,
+			wantErr:  true,
+		},
+		{
+			name:  "sort",
+			query: "?sort=most_commented",
+			want:  feedFilter{Sort: sortMostCommented},
+		},
+		{
+			name:    "unknown sort",
+			query:   "?sort=random",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/get/feed"+tt.query, nil)
+			got, err := parseFeedFilter(r, tt.viewerID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseFeedFilter(%q) error = nil, want error", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFeedFilter(%q) error = %v", tt.query, err)
+			}
+			if !equalFeedFilters(got, tt.want) {
+				t.Errorf("parseFeedFilter(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFeedFilterLikedUnauthorized(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/get/feed?liked=false", nil)
+	if _, err := parseFeedFilter(r, 0); err != errLikedFilterUnauthorized {
+		t.Errorf("parseFeedFilter error = %v, want errLikedFilterUnauthorized", err)
+	}
+}
+
+func equalFeedFilters(a feedFilter, b feedFilter) bool {
+	equalTime := func(x *time.Time, y *time.Time) bool {
+		return x == nil && y == nil || x != nil && y != nil && x.Equal(*y)
+	}
+	equalBool := func(x *bool, y *bool) bool {
+		return x == nil && y == nil || x != nil && y != nil && *x == *y
+	}
+	return slices.Equal(a.Usernames, b.Usernames) &&
+		equalTime(a.From, b.From) &&
+		equalTime(a.To, b.To) &&
+		equalBool(a.Liked, b.Liked) &&
+		a.Sort == b.Sort
+}
+
+func TestMergedKeyset(t *testing.T) {
+	postCursor := &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 9}
+	repostCursor := &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 3, Repost: true}
+
+	tests := []struct {
+		name       string
+		params     cursorParams
+		alias      string
+		repost     bool
+		wantFilter string
+		wantArgs   []interface{}
+	}{
+		{
+			name:       "same kind uses the full keyset",
+			params:     cursorParams{Sort: sortNewest, After: postCursor},
+			alias:      "p",
+			wantFilter: "(p.created_at < ? OR (p.created_at = ? AND p.id < ?))",
+			wantArgs:   []interface{}{postCursor.CreatedAt, postCursor.CreatedAt, postCursor.ID},
+		},
+		{
+			name:       "newest posts after a repost keep the same second",
+			params:     cursorParams{Sort: sortNewest, After: repostCursor},
+			alias:      "p",
+			wantFilter: "p.created_at <= ?",
+			wantArgs:   []interface{}{repostCursor.CreatedAt},
+		},
+		{
+			name:       "newest reposts after a post skip the same second",
+			params:     cursorParams{Sort: sortNewest, After: postCursor},
+			alias:      "r",
+			repost:     true,
+			wantFilter: "r.created_at < ?",
+			wantArgs:   []interface{}{postCursor.CreatedAt},
+		},
+		{
+			name:       "oldest reposts after a post keep the same second",
+			params:     cursorParams{Sort: sortOldest, After: postCursor},
+			alias:      "r",
+			repost:     true,
+			wantFilter: "r.created_at >= ?",
+			wantArgs:   []interface{}{postCursor.CreatedAt},
+		},
+		{
+			name:       "oldest posts after a repost skip the same second",
+			params:     cursorParams{Sort: sortOldest, After: repostCursor},
+			alias:      "p",
+			wantFilter: "p.created_at > ?",
+			wantArgs:   []interface{}{repostCursor.CreatedAt},
+		},
+		{
+			name:       "newest posts before a repost page backwards",
+			params:     cursorParams{Sort: sortNewest, Before: repostCursor},
+			alias:      "p",
+			wantFilter: "p.created_at > ?",
+			wantArgs:   []interface{}{repostCursor.CreatedAt},
+		},
+		{
+			name:       "first page",
+			params:     cursorParams{Sort: sortNewest},
+			alias:      "r",
+			repost:     true,
+			wantFilter: "TRUE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, args, _, _ := tt.params.mergedKeyset(tt.alias, tt.repost)
+			if filter != tt.wantFilter {
+				t.Errorf("filter = %q, want %q", filter, tt.wantFilter)
+			}
+			if !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestMergeFeedItems(t *testing.T) {
+	post := func(id int, createdAt string) models.FeedPostDTO {
+		return models.FeedPostDTO{ID: id, CreatedAt: createdAt}
+	}
+	repost := func(id int, postID int, createdAt string) models.FeedPostDTO {
+		return models.FeedPostDTO{
+			ID:        postID,
+			CreatedAt: "2025-01-01 00:00:00",
+			Repost:    &models.RepostDTO{ID: id, CreatedAt: createdAt},
+		}
+	}
+
+	posts := []models.FeedPostDTO{
+		post(1, "2026-01-01 10:00:00"),
+		post(2, "2026-01-01 12:00:00"),
+		post(3, "2026-01-01 12:00:00"),
+	}
+	reposts := []models.FeedPostDTO{
+		repost(1, 9, "2026-01-01 11:00:00"),
+		repost(2, 8, "2026-01-01 12:00:00"),
+	}
+
+	tests := []struct {
+		name      string
+		ascending bool
+		want      []listCursor
+	}{
+		{
+			name: "newest first with reposts before posts in the same second",
+			want: []listCursor{
+				{CreatedAt: "2026-01-01 12:00:00", ID: 2, Repost: true},
+				{CreatedAt: "2026-01-01 12:00:00", ID: 3},
+				{CreatedAt: "2026-01-01 12:00:00", ID: 2},
+				{CreatedAt: "2026-01-01 11:00:00", ID: 1, Repost: true},
+				{CreatedAt: "2026-01-01 10:00:00", ID: 1},
+			},
+		},
+		{
+			name:      "oldest first",
+			ascending: true,
+			want: []listCursor{
+				{CreatedAt: "2026-01-01 10:00:00", ID: 1},
+				{CreatedAt: "2026-01-01 11:00:00", ID: 1, Repost: true},
+				{CreatedAt: "2026-01-01 12:00:00", ID: 2},
+				{CreatedAt: "2026-01-01 12:00:00", ID: 3},
+				{CreatedAt: "2026-01-01 12:00:00", ID: 2, Repost: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, cursors := mergeFeedItems(posts, reposts, tt.ascending)
+			if !slices.Equal(cursors, tt.want) {
+				t.Fatalf("cursors = %+v, want %+v", cursors, tt.want)
+			}
+			for i, item := range items {
+				if feedItemCursor(item) != cursors[i] {
+					t.Errorf("items[%d] = %+v does not match cursor %+v", i, item, cursors[i])
+				}
+			}
+		})
+	}
+
+	if posts[0].ID != 1 || posts[2].ID != 3 {
+		t.Errorf("mergeFeedItems reordered its input: %+v", posts)
+	}
+}
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+func FollowUser(w http.ResponseWriter, r *http.Request) {
+	setFollowing(w, r, true)
+}
+
+func UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	setFollowing(w, r, false)
+}
+
+func setFollowing(w http.ResponseWriter, r *http.Request, follow bool) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var targetUserID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE username = ?", username).Scan(&targetUserID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	if targetUserID == userID {
+		http.Error(w, "You cannot follow yourself", http.StatusBadRequest)
+		return
+	}
+
+	if follow {
+		if blocked, err := services.IsBlocked(ctx, targetUserID, userID); err != nil || blocked {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	var query string
+	var message string
+	if follow {
+		query = "INSERT IGNORE INTO follows (follower_id, following_id) VALUES (?, ?)"
+		message = "Kullanıcı takip edildi"
+	} else {
+		query = "DELETE FROM follows WHERE follower_id = ? AND following_id = ?"
+		message = "Kullanıcı takipten çıkarıldı"
+	}
+
+	result, err := globals.DB.ExecContext(ctx, query, userID, targetUserID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("setFollowing: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 && follow {
+		if err := services.NotifyUser(ctx, targetUserID, userID, models.EmailTypeNewFollower, 0); err != nil {
+			log.Printf("setFollowing: notification error: %v", err)
+		}
+	}
+
+	followerCount, followingCount, err := loadFollowCounts(ctx, targetUserID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"user_id":         targetUserID,
+			"username":        username,
+			"is_following":    follow,
+			"follower_count":  followerCount,
+			"following_count": followingCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetFollowers(w http.ResponseWriter, r *http.Request) {
+	listFollows(w, r, "following_id", "follower_id")
+}
+
+func GetFollowing(w http.ResponseWriter, r *http.Request) {
+	listFollows(w, r, "follower_id", "following_id")
+}
+
+func listFollows(w http.ResponseWriter, r *http.Request, matchColumn string, userColumn string) {
+	viewerID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	params, err := parseCursorParams(r, defaultPageSize, sortNewest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var targetUserID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE username = ?", username).Scan(&targetUserID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	canView, err := canViewProfile(ctx, targetUserID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if !canView {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	var total int
+	err = globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM follows f WHERE f."+matchColumn+" = ?", targetUserID).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("f", "")
+
+	query := `
+		SELECT
+			f.id,
+			u.id,
+			u.username,
+			f.created_at,
+			EXISTS(SELECT 1 FROM follows WHERE follower_id = ? AND following_id = u.id) as is_following
+		FROM follows f
+		JOIN users u ON f.` + userColumn + ` = u.id
+		WHERE f.` + matchColumn + ` = ? AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append([]interface{}{viewerID, targetUserID}, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var users []models.FollowUserDTO
+	var cursors []listCursor
+	for rows.Next() {
+		var user models.FollowUserDTO
+		var followID int
+		if err := rows.Scan(&followID, &user.UserID, &user.Username, &user.FollowedAt, &user.IsFollowing); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		users = append(users, user)
+		cursors = append(cursors, listCursor{CreatedAt: user.FollowedAt, ID: followID})
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	users, pagination := cursorPage(users, cursors, params, reversed, total)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"users":      users,
+			"pagination": pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func loadFollowCounts(ctx context.Context, userID int) (int, int, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM follows WHERE following_id = ?),
+			(SELECT COUNT(*) FROM follows WHERE follower_id = ?)
+	`
+	var followerCount, followingCount int
+	err := globals.DB.QueryRowContext(ctx, query, userID, userID).Scan(&followerCount, &followingCount)
+	return followerCount, followingCount, err
+}
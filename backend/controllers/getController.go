@@ -23,10 +23,10 @@ func GetMe(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	query := "SELECT username, email, notifications, is_verified, created_at FROM users WHERE id = ?"
+	query := "SELECT username, email, notifications, is_verified, is_private, created_at FROM users WHERE id = ?"
 	var user models.UserDTO
 
-	err = globals.DB.QueryRowContext(ctx, query, userID).Scan(&user.Username, &user.Email, &user.Notifications, &user.IsVerified, &user.CreatedAt)
+	err = globals.DB.QueryRowContext(ctx, query, userID).Scan(&user.Username, &user.Email, &user.Notifications, &user.IsVerified, &user.IsPrivate, &user.CreatedAt)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			http.Error(w, "Timeout", http.StatusInternalServerError)
@@ -45,6 +45,7 @@ func GetMe(w http.ResponseWriter, r *http.Request) {
 			"email": user.Email,
 			"notifications": user.Notifications,
 			"is_verified": user.IsVerified,
+			"is_private": user.IsPrivate,
 			"created_at": user.CreatedAt,
 		},
 	}
@@ -61,7 +62,7 @@ func GetMe(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetUserByID(w http.ResponseWriter, r *http.Request)  {
-	_, err := services.GetUserIDFromRequest(r)
+	viewerID, err := services.GetUserIDFromRequest(r)
 	if err != nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
@@ -72,17 +73,55 @@ func GetUserByID(w http.ResponseWriter, r *http.Request)  {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	query := "SELECT id, username, email, notifications, is_verified, created_at FROM users WHERE username = ?"
+	query := "SELECT id, username, email, notifications, is_verified, is_private, created_at FROM users WHERE username = ?"
 
 	var userID 	int
 	var user 	models.UserDTO
-	err = globals.DB.QueryRowContext(ctx, query, username).Scan(&userID, &user.Username, &user.Email, &user.Notifications, &user.IsVerified, &user.CreatedAt)
+	err = globals.DB.QueryRowContext(ctx, query, username).Scan(&userID, &user.Username, &user.Email, &user.Notifications, &user.IsVerified, &user.IsPrivate, &user.CreatedAt)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			http.Error(w, "Timeout", http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, "User not Found", http.StatusBadRequest)
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	followerCount, followingCount, err := loadFollowCounts(ctx, userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	var isFollowing bool
+	followQuery := "SELECT EXISTS(SELECT 1 FROM follows WHERE follower_id = ? AND following_id = ?)"
+	err = globals.DB.QueryRowContext(ctx, followQuery, viewerID, userID).Scan(&isFollowing)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	accessStatus, err := profileAccessStatus(ctx, userID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	var hasBlockedViewer, isBlocked, isMuted bool
+	relationQuery := `
+		SELECT
+			EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = ? AND blocked_id = ?),
+			EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = ? AND blocked_id = ?),
+			EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = ? AND muted_id = ?)
+	`
+	err = globals.DB.QueryRowContext(ctx, relationQuery, userID, viewerID, viewerID, userID, viewerID, userID).Scan(&hasBlockedViewer, &isBlocked, &isMuted)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if hasBlockedViewer {
+		http.Error(w, "User not found", http.StatusNotFound)
 		return
 	}
 
@@ -94,7 +133,14 @@ func GetUserByID(w http.ResponseWriter, r *http.Request)  {
 			"email": user.Email,
 			"notifications": user.Notifications,
 			"is_verified": user.IsVerified,
+			"is_private": user.IsPrivate,
+			"access_status": accessStatus,
 			"created_at": user.CreatedAt,
+			"follower_count": followerCount,
+			"following_count": followingCount,
+			"is_following": isFollowing,
+			"is_blocked": isBlocked,
+			"is_muted": isMuted,
 		},
 	}
 
@@ -124,11 +170,16 @@ func GetUserPosts(w http.ResponseWriter, r *http.Request)  {
 			p.id,
 			p.user_id,
 			p.image_path,
-			(SELECT COUNT(*) FROM posts_likes WHERE post_id = p.id) as like_count,
-			(SELECT COUNT(*) FROM posts_comments WHERE post_id = p.id) as comment_count,
+			p.like_count,
+			p.comment_count,
+			(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+			p.archived_at IS NOT NULL as is_archived,
+			p.is_published = FALSE as is_scheduled,
+			p.publish_at,
+			p.pinned_at IS NOT NULL as is_pinned,
 			p.created_at
 		FROM posts p
-		WHERE p.user_id = ?
+		WHERE p.user_id = ? AND p.deleted_at IS NULL
 		ORDER BY p.created_at DESC
 	`
 	rows, err := globals.DB.QueryContext(ctx, query, userID)
@@ -148,11 +199,22 @@ func GetUserPosts(w http.ResponseWriter, r *http.Request)  {
 			&post.ImagePath,
 			&post.LikeCount,
 			&post.CommentCount,
+			&post.RepostCount,
+			&post.IsArchived,
+			&post.IsScheduled,
+			&post.PublishAt,
+			&post.IsPinned,
 			&post.CreatedAt,
 		); err != nil {
 			http.Error(w, "DB Error", http.StatusInternalServerError)
 			return
 		}
+		if post.PublishAt != nil {
+			if scheduled, err := time.Parse(time.DateTime, *post.PublishAt); err == nil {
+				publishAt := scheduled.Format(time.RFC3339)
+				post.PublishAt = &publishAt
+			}
+		}
 		posts = append(posts, post)
 	}
 
@@ -161,6 +223,11 @@ func GetUserPosts(w http.ResponseWriter, r *http.Request)  {
 		return
 	}
 
+	if err := attachPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"data": map[string]interface{}{
@@ -180,7 +247,7 @@ func GetUserPosts(w http.ResponseWriter, r *http.Request)  {
 }
 
 func GetUserPostsByUsername(w http.ResponseWriter, r *http.Request) {
-	_, err := services.GetUserIDFromRequest(r)
+	viewerID, err := services.GetUserIDFromRequest(r)
 	if err != nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
@@ -202,17 +269,35 @@ func GetUserPostsByUsername(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if blocked, err := services.IsBlocked(ctx, targetUserID, viewerID); err != nil || blocked {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	canView, err := canViewProfile(ctx, targetUserID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if !canView {
+		http.Error(w, "This account is private", http.StatusForbidden)
+		return
+	}
+
 	query := `
 		SELECT
 			p.id,
 			p.user_id,
 			p.image_path,
-			(SELECT COUNT(*) FROM posts_likes WHERE post_id = p.id) as like_count,
-			(SELECT COUNT(*) FROM posts_comments WHERE post_id = p.id) as comment_count,
+			p.like_count,
+			p.comment_count,
+			(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+			p.pinned_at IS NOT NULL as is_pinned,
 			p.created_at
 		FROM posts p
-		WHERE p.user_id = ?
-		ORDER BY p.created_at DESC
+		WHERE p.user_id = ? AND ` + publicPostFilter + `
+		ORDER BY p.pinned_at IS NULL, p.pinned_at DESC, p.created_at DESC
 	`
 	rows, err := globals.DB.QueryContext(ctx, query, targetUserID)
 	if err != nil {
@@ -230,6 +315,8 @@ func GetUserPostsByUsername(w http.ResponseWriter, r *http.Request) {
 			&post.ImagePath,
 			&post.LikeCount,
 			&post.CommentCount,
+			&post.RepostCount,
+			&post.IsPinned,
 			&post.CreatedAt,
 		); err != nil {
 			http.Error(w, "DB Error", http.StatusInternalServerError)
@@ -243,6 +330,11 @@ func GetUserPostsByUsername(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := attachPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"data": map[string]interface{}{
@@ -262,6 +354,8 @@ func GetUserPostsByUsername(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetPostComments(w http.ResponseWriter, r *http.Request)  {
+	viewerID, _ := services.GetUserIDFromRequest(r)
+
 	postIDstr := r.PathValue("post_id")
 	postID, err := strconv.Atoi(postIDstr)
 	if err != nil {
@@ -269,38 +363,30 @@ func GetPostComments(w http.ResponseWriter, r *http.Request)  {
 		return
 	}
 
+	params, err := parseCursorParams(r, defaultCommentPageSize, sortOldest, sortOldest, sortNewest, sortTop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	query := `
-		SELECT c.id, c.user_id, u.username, c.comment, c.created_at
-		FROM posts_comments c
-		JOIN users u ON c.user_id = u.id
-		WHERE c.post_id = ?
-		ORDER BY c.created_at ASC
-	`
-	rows, err := globals.DB.QueryContext(ctx, query, postID)
+	postOwnerID, err := lookupVisiblePost(ctx, postID, viewerID)
 	if err != nil {
-		http.Error(w, "DB Error", http.StatusInternalServerError)
+		http.Error(w, "Post not found", http.StatusNotFound)
 		return
 	}
-	defer rows.Close()
 
-	var comments []models.PostCommentsDTO
+	hiddenFilter, hiddenArgs := visibleCommentFilter("c", postOwnerID, viewerID)
 
-	for rows.Next() {
-		var comment models.PostCommentsDTO
-		if err := rows.Scan(
-			&comment.ID,
-			&comment.UserID,
-			&comment.Username,
-			&comment.Comment,
-			&comment.CreatedAt,
-		); err != nil {
-			http.Error(w, "DB Error", http.StatusInternalServerError)
-			return
-		}
-		comments = append(comments, comment)
+	scope := "c.post_id = ? AND c.parent_id IS NULL AND " + hiddenFilter
+	scopeArgs := append([]interface{}{postID}, hiddenArgs...)
+
+	comments, pagination, err := loadCommentPage(ctx, scope, scopeArgs, params, postOwnerID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
 	}
 
 	jsonResponse := map[string]interface{}{
@@ -308,6 +394,8 @@ func GetPostComments(w http.ResponseWriter, r *http.Request)  {
 		"data": map[string]interface{}{
 			"comments": comments,
 			"count": len(comments),
+			"total_count": pagination.TotalCount,
+			"pagination": pagination,
 		},
 	}
 
@@ -322,60 +410,22 @@ func GetPostComments(w http.ResponseWriter, r *http.Request)  {
 	w.Write(responseBytes)
 }
 
-func GetFeed(w http.ResponseWriter, r *http.Request) {
-	userID, _ := services.GetUserIDFromRequest(r)
-
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 12
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
-			limit = l
-		}
-	}
-
-	offset := (page - 1) * limit
-
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-	defer cancel()
-
-	var totalPosts int
-	countQuery := "SELECT COUNT(*) FROM posts"
-	err := globals.DB.QueryRowContext(ctx, countQuery).Scan(&totalPosts)
-	if err != nil {
-		http.Error(w, "DB Error", http.StatusInternalServerError)
-		return
-	}
-
-	query := `
-		SELECT
-			p.id,
-			p.user_id,
-			u.username,
-			p.image_path,
-			(SELECT COUNT(*) FROM posts_likes WHERE post_id = p.id) as like_count,
-			(SELECT COUNT(*) FROM posts_comments WHERE post_id = p.id) as comment_count,
-			EXISTS(SELECT 1 FROM posts_likes WHERE post_id = p.id AND user_id = ?) as is_liked,
-			p.created_at
-		FROM posts p
-		JOIN users u ON p.user_id = u.id
-		ORDER BY p.created_at DESC
-		LIMIT ? OFFSET ?
-	`
-
-	rows, err := globals.DB.QueryContext(ctx, query, userID, limit, offset)
+const feedPostColumns = `
+	p.id,
+	p.user_id,
+	u.username,
+	p.image_path,
+	p.like_count,
+	p.comment_count,
+	(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+	EXISTS(SELECT 1 FROM posts_likes WHERE post_id = p.id AND user_id = ?) as is_liked,
+	EXISTS(SELECT 1 FROM posts_saves WHERE post_id = p.id AND user_id = ?) as is_saved,
+	p.created_at`
+
+func queryFeedPosts(ctx context.Context, query string, args ...interface{}) ([]models.FeedPostDTO, error) {
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		http.Error(w, "DB Error", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -383,6 +433,7 @@ func GetFeed(w http.ResponseWriter, r *http.Request) {
 
 	for rows.Next() {
 		var post models.FeedPostDTO
+		var repost repostColumns
 		if err := rows.Scan(
 			&post.ID,
 			&post.UserID,
@@ -390,38 +441,161 @@ func GetFeed(w http.ResponseWriter, r *http.Request) {
 			&post.ImagePath,
 			&post.LikeCount,
 			&post.CommentCount,
+			&post.RepostCount,
 			&post.IsLiked,
+			&post.IsSaved,
 			&post.CreatedAt,
+			&repost.ID,
+			&repost.UserID,
+			&repost.Username,
+			&repost.Commentary,
+			&repost.CreatedAt,
 		); err != nil {
-			http.Error(w, "DB Error", http.StatusInternalServerError)
-			return
+			return nil, err
 		}
+		post.Repost = repost.dto()
 		posts = append(posts, post)
 	}
 
-	if err := rows.Err(); err != nil {
+	return posts, rows.Err()
+}
+
+func loadRankedFeed(ctx context.Context, filter feedFilter, limit int, offset int, viewerID int) ([]models.FeedPostDTO, int, error) {
+	filterQuery, filterArgs := filter.where(viewerID)
+
+	var total int
+	countQuery := "SELECT COUNT(*) " + filter.from() + " WHERE " + filterQuery
+	if err := globals.DB.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + feedPostColumns + `, NULL, NULL, NULL, NULL, NULL
+		` + filter.from() + `
+		WHERE ` + filterQuery + `
+		ORDER BY ` + filter.orderBy() + `
+		LIMIT ? OFFSET ?
+	`
+
+	args := append([]interface{}{viewerID, viewerID}, filterArgs...)
+	args = append(args, limit, offset)
+
+	posts, err := queryFeedPosts(ctx, query, args...)
+	return posts, total, err
+}
+
+func loadMergedFeed(ctx context.Context, filter feedFilter, params cursorParams, viewerID int) ([]models.FeedPostDTO, models.CursorInfo, error) {
+	postQuery, postArgs := filter.where(viewerID)
+	repostQuery, repostArgs := filter.repostWhere(viewerID)
+
+	var totalPosts, totalReposts int
+	countQuery := "SELECT COUNT(*) " + filter.from() + " WHERE " + postQuery
+	if err := globals.DB.QueryRowContext(ctx, countQuery, postArgs...).Scan(&totalPosts); err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+	countQuery = "SELECT COUNT(*) " + filter.repostFrom() + " WHERE " + repostQuery
+	if err := globals.DB.QueryRowContext(ctx, countQuery, repostArgs...).Scan(&totalReposts); err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	postCursor, postCursorArgs, postOrder, reversed := params.mergedKeyset("p", false)
+	query := `
+		SELECT ` + feedPostColumns + `, NULL, NULL, NULL, NULL, NULL
+		` + filter.from() + `
+		WHERE ` + postQuery + ` AND ` + postCursor + `
+		ORDER BY ` + postOrder + `
+		LIMIT ?
+	`
+
+	args := append([]interface{}{viewerID, viewerID}, postArgs...)
+	args = append(args, postCursorArgs...)
+	args = append(args, params.Limit+1)
+
+	posts, err := queryFeedPosts(ctx, query, args...)
+	if err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	repostCursor, repostCursorArgs, repostOrder, _ := params.mergedKeyset("r", true)
+	query = `
+		SELECT ` + feedPostColumns + `, r.id, r.user_id, ru.username, r.commentary, r.created_at
+		` + filter.repostFrom() + `
+		WHERE ` + repostQuery + ` AND ` + repostCursor + `
+		ORDER BY ` + repostOrder + `
+		LIMIT ?
+	`
+
+	args = append([]interface{}{viewerID, viewerID}, repostArgs...)
+	args = append(args, repostCursorArgs...)
+	args = append(args, params.Limit+1)
+
+	reposts, err := queryFeedPosts(ctx, query, args...)
+	if err != nil {
+		return nil, models.CursorInfo{}, err
+	}
+
+	items, cursors := mergeFeedItems(posts, reposts, params.ascending())
+	items, info := cursorPage(items, cursors, params, reversed, totalPosts+totalReposts)
+	return items, info, nil
+}
+
+func GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID, _ := services.GetUserIDFromRequest(r)
+
+	page, limit, offset := parsePagination(r)
+
+	filter, err := parseFeedFilter(r, userID)
+	if err != nil {
+		if errors.Is(err, errLikedFilterUnauthorized) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var params cursorParams
+	if filter.includesReposts() {
+		params, err = parseCursorParams(r, defaultPageSize, filter.Sort, feedSorts...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var posts []models.FeedPostDTO
+	var pagination interface{}
+
+	if filter.includesReposts() {
+		posts, pagination, err = loadMergedFeed(ctx, filter, params, userID)
+	} else {
+		var totalPosts int
+		posts, totalPosts, err = loadRankedFeed(ctx, filter, limit, offset, userID)
+		pagination = newPaginationInfo(page, limit, totalPosts)
+	}
+	if err != nil {
 		http.Error(w, "DB Error", http.StatusInternalServerError)
 		return
 	}
 
-	totalPages := (totalPosts + limit - 1) / limit
-	if totalPages == 0 {
-		totalPages = 1
+	if err := attachFeedPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
 	}
 
-	pagination := models.PaginationInfo{
-		CurrentPage: page,
-		TotalPages:  totalPages,
-		TotalPosts:  totalPosts,
-		Limit:       limit,
-		HasNext:     page < totalPages,
-		HasPrev:     page > 1,
+	if err := attachFeedReactions(ctx, posts, userID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
 	}
 
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"data": map[string]interface{}{
 			"posts":      posts,
+			"sort":       filter.Sort,
 			"pagination": pagination,
 		},
 	}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func insertLike(ctx context.Context, userID int, postID int, reaction string) (bool, int, error) {
+	query := "INSERT IGNORE INTO posts_likes (user_id, post_id, reaction) VALUES (?, ?, ?)"
+	return writeLike(ctx, postID, query, userID, postID, reaction)
+}
+
+func deleteLike(ctx context.Context, userID int, postID int) (bool, int, error) {
+	query := "DELETE FROM posts_likes WHERE user_id = ? AND post_id = ?"
+	return writeLike(ctx, postID, query, userID, postID)
+}
+
+func writeLike(ctx context.Context, postID int, query string, args ...interface{}) (bool, int, error) {
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, 0, err
+	}
+	if rowsAffected > 0 {
+		if err := refreshPostLikeCount(ctx, tx, postID); err != nil {
+			return false, 0, err
+		}
+	}
+
+	var likeCount int
+	if err := tx.QueryRowContext(ctx, "SELECT like_count FROM posts WHERE id = ?", postID).Scan(&likeCount); err != nil {
+		return false, 0, err
+	}
+
+	return rowsAffected > 0, likeCount, tx.Commit()
+}
+
+func PutLike(w http.ResponseWriter, r *http.Request) {
+	setPostLiked(w, r, true)
+}
+
+func DeleteLike(w http.ResponseWriter, r *http.Request) {
+	setPostLiked(w, r, false)
+}
+
+func setPostLiked(w http.ResponseWriter, r *http.Request, liked bool) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	toUserID, err := lookupVisiblePost(ctx, postID, userID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	var changed bool
+	var likeCount int
+	if liked {
+		changed, likeCount, err = insertLike(ctx, userID, postID, reactionHeart)
+	} else {
+		changed, likeCount, err = deleteLike(ctx, userID, postID)
+	}
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("setPostLiked: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if changed && liked {
+		if err := services.NotifyUser(ctx, toUserID, userID, models.EmailTypePostLiked, postID); err != nil {
+			log.Printf("setPostLiked: notification error: %v", err)
+		}
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"post_id":    postID,
+			"user_id":    userID,
+			"is_liked":   liked,
+			"changed":    changed,
+			"like_count": likeCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetPostLikes(w http.ResponseWriter, r *http.Request) {
+	viewerID, _ := services.GetUserIDFromRequest(r)
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseCursorParams(r, defaultPageSize, sortNewest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := lookupVisiblePost(ctx, postID, viewerID); err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	var likeCount int
+	err = globals.DB.QueryRowContext(ctx, "SELECT like_count FROM posts WHERE id = ?", postID).Scan(&likeCount)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	var viewerHasLiked bool
+	if viewerID != 0 {
+		err = globals.DB.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM posts_likes WHERE post_id = ? AND user_id = ?)", postID, viewerID).Scan(&viewerHasLiked)
+		if err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("l", "")
+
+	query := `
+		SELECT l.id, l.user_id, u.username, l.reaction, l.created_at
+		FROM posts_likes l
+		JOIN users u ON l.user_id = u.id
+		WHERE l.post_id = ? AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append([]interface{}{postID}, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var likers []models.PostLikerDTO
+	var cursors []listCursor
+	for rows.Next() {
+		var liker models.PostLikerDTO
+		var likeID int
+		if err := rows.Scan(&likeID, &liker.UserID, &liker.Username, &liker.Reaction, &liker.LikedAt); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		liker.IsViewer = viewerID != 0 && liker.UserID == viewerID
+		likers = append(likers, liker)
+		cursors = append(cursors, listCursor{CreatedAt: liker.LikedAt, ID: likeID})
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	likers, pagination := cursorPage(likers, cursors, params, reversed, likeCount)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"likes":            likers,
+			"like_count":       likeCount,
+			"viewer_has_liked": viewerHasLiked,
+			"pagination":       pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
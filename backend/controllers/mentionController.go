@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxCommentMentions         = 10
+	defaultMentionSuggestions  = 8
+	maxMentionSuggestions      = 20
+	mentionInteractionLookback = 90
+)
+
+var mentionRegex = regexp.MustCompile(`(^|[^a-zA-Z0-9_@])@([a-zA-Z0-9_]{3,30})\b`)
+
+var mentionPrefixRegex = regexp.MustCompile(`^[a-zA-Z0-9_]{0,30}$`)
+
+type parsedMention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func parseMentions(text string) []parsedMention {
+	var mentions []parsedMention
+	for _, match := range mentionRegex.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[4]-1, match[5]
+		mentions = append(mentions, parsedMention{
+			Username: text[match[4]:match[5]],
+			Offset:   utf8.RuneCountInString(text[:start]),
+			Length:   utf8.RuneCountInString(text[start:end]),
+		})
+		if len(mentions) == maxCommentMentions {
+			break
+		}
+	}
+	return mentions
+}
+
+func storeCommentMentions(ctx context.Context, tx *sql.Tx, commentID int, text string) ([]models.CommentMentionDTO, []int, error) {
+	mentions := []models.CommentMentionDTO{}
+
+	previous := make(map[int]bool)
+	rows, err := tx.QueryContext(ctx, "SELECT user_id FROM comment_mentions WHERE comment_id = ?", commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		var mentionedID int
+		if err := rows.Scan(&mentionedID); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		previous[mentionedID] = true
+	}
+	rows.Close()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM comment_mentions WHERE comment_id = ?", commentID); err != nil {
+		return nil, nil, err
+	}
+
+	parsed := parseMentions(text)
+	if len(parsed) == 0 {
+		return mentions, nil, nil
+	}
+
+	usernames := make([]interface{}, len(parsed))
+	for i, mention := range parsed {
+		usernames[i] = mention.Username
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(parsed)), ",")
+	rows, err = tx.QueryContext(ctx, "SELECT id, username FROM users WHERE username IN ("+placeholders+")", usernames...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := make(map[string]models.CommentMentionDTO)
+	for rows.Next() {
+		var user models.CommentMentionDTO
+		if err := rows.Scan(&user.UserID, &user.Username); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		users[strings.ToLower(user.Username)] = user
+	}
+	rows.Close()
+
+	var newlyMentioned []int
+	seen := make(map[int]bool)
+	for _, mention := range parsed {
+		user, ok := users[strings.ToLower(mention.Username)]
+		if !ok {
+			continue
+		}
+		user.Offset = mention.Offset
+		user.Length = mention.Length
+
+		insertQuery := "INSERT INTO comment_mentions (comment_id, user_id, mention_offset, mention_length) VALUES (?, ?, ?, ?)"
+		if _, err := tx.ExecContext(ctx, insertQuery, commentID, user.UserID, user.Offset, user.Length); err != nil {
+			return nil, nil, err
+		}
+		mentions = append(mentions, user)
+
+		if previous[user.UserID] || seen[user.UserID] {
+			continue
+		}
+		seen[user.UserID] = true
+		newlyMentioned = append(newlyMentioned, user.UserID)
+	}
+
+	return mentions, newlyMentioned, nil
+}
+
+func notifyCommentMentions(ctx context.Context, postID int, postOwnerID int, authorID int, userIDs []int) {
+	for _, mentionedID := range userIDs {
+		if canView, err := canViewProfile(ctx, postOwnerID, mentionedID); err != nil || !canView {
+			continue
+		}
+		if err := services.NotifyUser(ctx, mentionedID, authorID, models.EmailTypeCommentMentioned, postID); err != nil {
+			log.Printf("notifyCommentMentions: notification error: %v", err)
+		}
+	}
+}
+
+func attachCommentMentions(ctx context.Context, comments []models.PostCommentsDTO) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	commentIDs := make([]int, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+	}
+
+	placeholders, args := inClause(commentIDs)
+	query := `
+		SELECT cm.comment_id, u.id, u.username, cm.mention_offset, cm.mention_length
+		FROM comment_mentions cm
+		JOIN users u ON cm.user_id = u.id
+		WHERE cm.comment_id IN (` + placeholders + `)
+		ORDER BY cm.comment_id, cm.mention_offset
+	`
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	mentions := make(map[int][]models.CommentMentionDTO)
+	for rows.Next() {
+		var commentID int
+		var mention models.CommentMentionDTO
+		if err := rows.Scan(&commentID, &mention.UserID, &mention.Username, &mention.Offset, &mention.Length); err != nil {
+			return err
+		}
+		mentions[commentID] = append(mentions[commentID], mention)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].ID]
+		if comments[i].Mentions == nil {
+			comments[i].Mentions = []models.CommentMentionDTO{}
+		}
+	}
+	return nil
+}
+
+func GetMentionSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	prefix := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("q")), "@")
+	if !mentionPrefixRegex.MatchString(prefix) {
+		http.Error(w, "Invalid query", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultMentionSuggestions
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > maxMentionSuggestions {
+			http.Error(w, "limit must be between 1 and 20", http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	blockFilter, blockArgs := notBlockedFilter("u.id", userID)
+	interactionQuery := `
+		SELECT u.id, u.username, MAX(i.interacted_at) as last_interacted_at
+		FROM (
+			SELECT p.user_id as other_id, l.created_at as interacted_at
+			FROM posts_likes l JOIN posts p ON p.id = l.post_id
+			WHERE l.user_id = ?
+			UNION ALL
+			SELECT p.user_id, c.created_at
+			FROM posts_comments c JOIN posts p ON p.id = c.post_id
+			WHERE c.user_id = ?
+			UNION ALL
+			SELECT c.user_id, c.created_at
+			FROM posts_comments c JOIN posts p ON p.id = c.post_id
+			WHERE p.user_id = ?
+			UNION ALL
+			SELECT following_id, created_at FROM follows WHERE follower_id = ?
+			UNION ALL
+			SELECT IF(c.user_a_id = ?, c.user_b_id, c.user_a_id), c.last_message_at
+			FROM conversations c
+			WHERE (c.user_a_id = ? OR c.user_b_id = ?) AND c.last_message_at IS NOT NULL
+		) i
+		JOIN users u ON u.id = i.other_id
+		WHERE i.interacted_at >= DATE_SUB(NOW(), INTERVAL ? DAY)
+			AND u.id <> ? AND u.username LIKE ? AND ` + blockFilter + `
+		GROUP BY u.id, u.username
+		ORDER BY last_interacted_at DESC
+		LIMIT ?
+	`
+	args := []interface{}{userID, userID, userID, userID, userID, userID, userID, mentionInteractionLookback, userID, strings.ReplaceAll(prefix, "_", `\_`) + "%"}
+	args = append(args, blockArgs...)
+	args = append(args, limit)
+
+	rows, err := globals.DB.QueryContext(ctx, interactionQuery, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	suggestions := []models.MentionSuggestionDTO{}
+	seen := map[int]bool{userID: true}
+	for rows.Next() {
+		var suggestion models.MentionSuggestionDTO
+		var lastInteractedAt string
+		if err := rows.Scan(&suggestion.UserID, &suggestion.Username, &lastInteractedAt); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		suggestion.RecentlyInteracted = true
+		suggestions = append(suggestions, suggestion)
+		seen[suggestion.UserID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(suggestions) < limit && prefix != "" {
+		exclude, err := blockedUserIDs(ctx, userID)
+		if err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		for id := range seen {
+			exclude[id] = true
+		}
+
+		for _, match := range services.SearchUsers(prefix, exclude) {
+			if len(suggestions) == limit || match.MatchType == services.MatchFuzzy {
+				break
+			}
+			suggestions = append(suggestions, models.MentionSuggestionDTO{UserID: match.ID, Username: match.Username})
+		}
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"users": suggestions,
+			"query": prefix,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
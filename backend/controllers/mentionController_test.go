@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	var many []string
+	var manyWant []parsedMention
+	for i := 0; i < maxCommentMentions+2; i++ {
+		username := fmt.Sprintf("user%02d", i)
+		many = append(many, "@"+username)
+		if i < maxCommentMentions {
+			manyWant = append(manyWant, parsedMention{Username: username, Offset: i * 8, Length: 7})
+		}
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []parsedMention
+	}{
+		{"no mentions", "nice photo", nil},
+		{"start of text", "@alice nice", []parsedMention{{"alice", 0, 6}}},
+		{"middle of text", "hi @bob_1!", []parsedMention{{"bob_1", 3, 6}}},
+		{"several", "@ann and @ben", []parsedMention{{"ann", 0, 4}, {"ben", 9, 4}}},
+		{"too short", "@ab hi", nil},
+		{"too long", "@" + strings.Repeat("a", 31), nil},
+		{"email address", "mail me at me@example.com", nil},
+		{"double at", "@@alice", nil},
+		{"after punctuation", "(@alice)", []parsedMention{{"alice", 1, 6}}},
+		{"offsets count runes", "çok güzel @ayşe_fan @deniz", []parsedMention{{"deniz", 20, 6}}},
+		{"capped", strings.Join(many, " "), manyWant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMentions(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("parseMentions(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,563 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxMessageLength = 1000
+
+func StartConversation(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.StartConversationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var peerID int
+	var peerUsername string
+	err = globals.DB.QueryRowContext(ctx, "SELECT id, username FROM users WHERE username = ?", strings.TrimSpace(req.Username)).Scan(&peerID, &peerUsername)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	if peerID == userID {
+		http.Error(w, "You cannot message yourself", http.StatusBadRequest)
+		return
+	}
+
+	if blocked, err := services.IsBlocked(ctx, peerID, userID); err != nil || blocked {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	userA, userB := userID, peerID
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO conversations (user_a_id, user_b_id) VALUES (?, ?)", userA, userB); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("StartConversation: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var conversationID int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM conversations WHERE user_a_id = ? AND user_b_id = ?", userA, userB).Scan(&conversationID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	participantQuery := "INSERT IGNORE INTO conversation_participants (conversation_id, user_id) VALUES (?, ?), (?, ?)"
+	if _, err := tx.ExecContext(ctx, participantQuery, conversationID, userA, conversationID, userB); err != nil {
+		log.Printf("StartConversation: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"conversation_id": conversationID,
+			"user_id":         peerID,
+			"username":        peerUsername,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func conversationPeer(ctx context.Context, conversationID int, userID int) (int, error) {
+	query := "SELECT IF(user_a_id = ?, user_b_id, user_a_id) FROM conversations WHERE id = ? AND (user_a_id = ? OR user_b_id = ?)"
+	var peerID int
+	err := globals.DB.QueryRowContext(ctx, query, userID, conversationID, userID, userID).Scan(&peerID)
+	return peerID, err
+}
+
+func SendMessage(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	conversationIDstr := r.PathValue("conversation_id")
+	conversationID, err := strconv.Atoi(conversationIDstr)
+	if err != nil {
+		http.Error(w, "Invalid conversation ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SendMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" && req.PostID == nil {
+		http.Error(w, "Message cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	if len([]rune(req.Body)) > maxMessageLength {
+		http.Error(w, "Too long message", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	peerID, err := conversationPeer(ctx, conversationID, userID)
+	if err != nil {
+		http.Error(w, "Conversation not found", http.StatusNotFound)
+		return
+	}
+
+	if blocked, err := services.IsBlocked(ctx, peerID, userID); err != nil || blocked {
+		http.Error(w, "You cannot message this user", http.StatusForbidden)
+		return
+	}
+
+	if req.PostID != nil {
+		if _, err := lookupVisiblePost(ctx, *req.PostID, userID); err != nil {
+			http.Error(w, "Post not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO messages (conversation_id, sender_id, body, shared_post_id) VALUES (?, ?, ?, ?)", conversationID, userID, req.Body, req.PostID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SendMessage: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	messageID, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error getting messageID", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE conversations SET last_message_at = NOW() WHERE id = ?", conversationID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	readQuery := "UPDATE conversation_participants SET last_read_message_id = ? WHERE conversation_id = ? AND user_id = ?"
+	if _, err := tx.ExecContext(ctx, readQuery, messageID, conversationID, userID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Mesaj gönderildi",
+		"data": map[string]interface{}{
+			"message_id":      messageID,
+			"conversation_id": conversationID,
+			"sender_id":       userID,
+			"body":            req.Body,
+			"post_id":         req.PostID,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBytes)
+}
+
+func GetConversationMessages(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	conversationIDstr := r.PathValue("conversation_id")
+	conversationID, err := strconv.Atoi(conversationIDstr)
+	if err != nil {
+		http.Error(w, "Invalid conversation ID", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseCursorParams(r, defaultCommentPageSize, sortNewest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := conversationPeer(ctx, conversationID, userID); err != nil {
+		http.Error(w, "Conversation not found", http.StatusNotFound)
+		return
+	}
+
+	var total int
+	err = globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM messages WHERE conversation_id = ?", conversationID).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	keysetFilter, keysetArgs, order, reversed := params.keyset("m", "")
+	visibleFilter, visibleArgs := viewablePostFilter(userID)
+
+	query := `
+		SELECT
+			m.id,
+			m.sender_id,
+			m.body,
+			m.created_at,
+			p.id,
+			p.user_id,
+			su.username,
+			p.image_path
+		FROM messages m
+		LEFT JOIN posts p ON p.id = m.shared_post_id AND ` + visibleFilter + `
+		LEFT JOIN users su ON su.id = p.user_id
+		WHERE m.conversation_id = ? AND ` + keysetFilter + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args := append(visibleArgs, conversationID)
+	args = append(args, keysetArgs...)
+	args = append(args, params.Limit+1)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var messages []models.MessageDTO
+	var cursors []listCursor
+	for rows.Next() {
+		message := models.MessageDTO{ConversationID: conversationID}
+		var postID, postUserID *int
+		var postUsername, postImagePath *string
+		if err := rows.Scan(
+			&message.ID,
+			&message.SenderID,
+			&message.Body,
+			&message.CreatedAt,
+			&postID,
+			&postUserID,
+			&postUsername,
+			&postImagePath,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		if postID != nil && postUserID != nil && postUsername != nil && postImagePath != nil {
+			message.SharedPost = &models.SharedPostDTO{
+				ID:        *postID,
+				UserID:    *postUserID,
+				Username:  *postUsername,
+				ImagePath: *postImagePath,
+			}
+		}
+		message.IsMine = message.SenderID == userID
+		messages = append(messages, message)
+		cursors = append(cursors, listCursor{CreatedAt: message.CreatedAt, ID: message.ID})
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	messages, pagination := cursorPage(messages, cursors, params, reversed, total)
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"messages":   messages,
+			"pagination": pagination,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func MarkConversationRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	conversationIDstr := r.PathValue("conversation_id")
+	conversationID, err := strconv.Atoi(conversationIDstr)
+	if err != nil {
+		http.Error(w, "Invalid conversation ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := conversationPeer(ctx, conversationID, userID); err != nil {
+		http.Error(w, "Conversation not found", http.StatusNotFound)
+		return
+	}
+
+	query := `
+		UPDATE conversation_participants
+		SET last_read_message_id = (SELECT COALESCE(MAX(id), 0) FROM messages WHERE conversation_id = ?)
+		WHERE conversation_id = ? AND user_id = ?
+	`
+	if _, err := globals.DB.ExecContext(ctx, query, conversationID, conversationID, userID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("MarkConversationRead: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	unreadCount, err := unreadMessageCount(ctx, userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Mesajlar okundu olarak işaretlendi",
+		"data": map[string]interface{}{
+			"conversation_id": conversationID,
+			"unread_count":    unreadCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+const conversationListFrom = `
+	FROM conversation_participants cp
+	JOIN conversations c ON c.id = cp.conversation_id
+	JOIN users u ON u.id = IF(c.user_a_id = cp.user_id, c.user_b_id, c.user_a_id)
+`
+
+const conversationUnreadCount = `
+	(SELECT COUNT(*) FROM messages m
+	WHERE m.conversation_id = c.id AND m.id > cp.last_read_message_id AND m.sender_id <> cp.user_id)
+`
+
+func GetConversations(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page, limit, offset := parsePagination(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	blockFilter, blockArgs := notBlockedFilter("u.id", userID)
+	filter := "cp.user_id = ? AND " + blockFilter
+	filterArgs := append([]interface{}{userID}, blockArgs...)
+
+	var total int
+	err = globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) "+conversationListFrom+" WHERE "+filter, filterArgs...).Scan(&total)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := `
+		SELECT
+			c.id,
+			u.id,
+			u.username,
+			(SELECT m.body FROM messages m WHERE m.conversation_id = c.id ORDER BY m.id DESC LIMIT 1) as last_message,
+			c.last_message_at,
+			` + conversationUnreadCount + ` as unread_count,
+			c.created_at
+		` + conversationListFrom + `
+		WHERE ` + filter + `
+		ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+		LIMIT ? OFFSET ?
+	`
+	args := append(filterArgs, limit, offset)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	conversations := []models.ConversationDTO{}
+	for rows.Next() {
+		var conversation models.ConversationDTO
+		if err := rows.Scan(
+			&conversation.ID,
+			&conversation.UserID,
+			&conversation.Username,
+			&conversation.LastMessage,
+			&conversation.LastMessageAt,
+			&conversation.UnreadCount,
+			&conversation.CreatedAt,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		conversations = append(conversations, conversation)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"conversations": conversations,
+			"pagination":    newPaginationInfo(page, limit, total),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetUnreadMessageCount(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	unreadCount, err := unreadMessageCount(ctx, userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"unread_count": unreadCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func unreadMessageCount(ctx context.Context, userID int) (int, error) {
+	blockFilter, blockArgs := notBlockedFilter("u.id", userID)
+	query := "SELECT COALESCE(SUM(" + conversationUnreadCount + "), 0) " + conversationListFrom + " WHERE cp.user_id = ? AND " + blockFilter
+
+	var count int
+	err := globals.DB.QueryRowContext(ctx, query, append([]interface{}{userID}, blockArgs...)...).Scan(&count)
+	return count, err
+}
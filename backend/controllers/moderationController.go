@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func HideComment(w http.ResponseWriter, r *http.Request) {
+	setCommentHidden(w, r, true)
+}
+
+func UnhideComment(w http.ResponseWriter, r *http.Request) {
+	setCommentHidden(w, r, false)
+}
+
+func setCommentHidden(w http.ResponseWriter, r *http.Request, hidden bool) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	commentIDstr := r.PathValue("comment_id")
+	commentID, err := strconv.Atoi(commentIDstr)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	checkQuery := "SELECT p.user_id FROM posts_comments c JOIN posts p ON c.post_id = p.id WHERE c.id = ? AND p.deleted_at IS NULL"
+	var postOwnerID int
+	err = globals.DB.QueryRowContext(ctx, checkQuery, commentID).Scan(&postOwnerID)
+	if err != nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if postOwnerID != userID && !isModerator(ctx, userID) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	query := "UPDATE posts_comments SET is_hidden = ? WHERE id = ?"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	if _, err := exec.ExecContext(ctx, hidden, commentID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("setCommentHidden: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	message := "Yorum gizlendi"
+	if !hidden {
+		message = "Yorum yeniden görünür yapıldı"
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"comment_id": commentID,
+			"is_hidden":  hidden,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func SetCommentPolicy(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CommentPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	switch req.Policy {
+	case commentPolicyEveryone, commentPolicyVerified, commentPolicyNobody:
+	default:
+		http.Error(w, "Policy must be one of everyone, verified or nobody", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	checkQuery := "SELECT user_id FROM posts WHERE id = ? AND deleted_at IS NULL"
+	var postOwnerID int
+	err = globals.DB.QueryRowContext(ctx, checkQuery, postID).Scan(&postOwnerID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if postOwnerID != userID {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	query := "UPDATE posts SET comment_policy = ? WHERE id = ? AND user_id = ?"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	if _, err := exec.ExecContext(ctx, req.Policy, postID, userID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SetCommentPolicy: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Yorum ayarları güncellendi",
+		"data": map[string]interface{}{
+			"post_id": postID,
+			"policy":  req.Policy,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,245 @@
+package controllers
+
+import (
+	"camagru/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const defaultPageSize = 12
+const maxPageSize = 50
+
+func parsePagination(r *http.Request) (int, int, int) {
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page := 1
+	limit := defaultPageSize
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxPageSize {
+			limit = l
+		}
+	}
+
+	return page, limit, (page - 1) * limit
+}
+
+func newPaginationInfo(page int, limit int, total int) models.PaginationInfo {
+	totalPages := (total + limit - 1) / limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return models.PaginationInfo{
+		CurrentPage: page,
+		TotalPages:  totalPages,
+		TotalPosts:  total,
+		Limit:       limit,
+		HasNext:     page < totalPages,
+		HasPrev:     page > 1,
+	}
+}
+
+const (
+	sortOldest = "oldest"
+	sortNewest = "newest"
+	sortTop    = "top"
+)
+
+type listCursor struct {
+	CreatedAt string `json:"t"`
+	ID        int    `json:"id"`
+	Score     int    `json:"s,omitempty"`
+	Repost    bool   `json:"r,omitempty"`
+}
+
+type cursorParams struct {
+	Limit  int
+	Sort   string
+	After  *listCursor
+	Before *listCursor
+}
+
+func encodeCursor(cursor listCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(encoded string) (*listCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.CreatedAt == "" || cursor.ID <= 0 {
+		return nil, errInvalidCursor
+	}
+	return &cursor, nil
+}
+
+var errInvalidCursor = errors.New("Invalid cursor")
+
+func parseCursorParams(r *http.Request, defaultLimit int, defaultSort string, sorts ...string) (cursorParams, error) {
+	query := r.URL.Query()
+	params := cursorParams{Limit: defaultLimit, Sort: defaultSort}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > maxPageSize {
+			return params, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+		params.Limit = l
+	}
+
+	if len(sorts) == 0 {
+		sorts = []string{sortOldest, sortNewest}
+	}
+	if sort := query.Get("sort"); sort != "" {
+		if !slices.Contains(sorts, sort) {
+			return params, fmt.Errorf("sort must be one of %s", strings.Join(sorts, ", "))
+		}
+		params.Sort = sort
+	}
+
+	after := query.Get("after")
+	before := query.Get("before")
+	if after != "" && before != "" {
+		return params, errors.New("after and before cannot be combined")
+	}
+
+	var err error
+	if after != "" {
+		if params.After, err = decodeCursor(after); err != nil {
+			return params, err
+		}
+	}
+	if before != "" {
+		if params.Before, err = decodeCursor(before); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+func (p cursorParams) keyset(alias string, scoreColumn string) (string, []interface{}, string, bool) {
+	ascending := p.Sort == sortOldest
+	cursor := p.After
+	reversed := false
+	if p.Before != nil {
+		cursor = p.Before
+		ascending = !ascending
+		reversed = true
+	}
+
+	direction, op := "DESC", "<"
+	if ascending {
+		direction, op = "ASC", ">"
+	}
+
+	if p.Sort == sortTop {
+		order := fmt.Sprintf("%[1]s %[2]s, %[3]s.id %[2]s", scoreColumn, direction, alias)
+		if cursor == nil {
+			return "TRUE", nil, order, reversed
+		}
+		condition := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s.id %[2]s ?))", scoreColumn, op, alias)
+		return condition, []interface{}{cursor.Score, cursor.Score, cursor.ID}, order, reversed
+	}
+
+	order := fmt.Sprintf("%[1]s.created_at %[2]s, %[1]s.id %[2]s", alias, direction)
+	if cursor == nil {
+		return "TRUE", nil, order, reversed
+	}
+	condition := fmt.Sprintf("(%[1]s.created_at %[2]s ? OR (%[1]s.created_at = ? AND %[1]s.id %[2]s ?))", alias, op)
+	return condition, []interface{}{cursor.CreatedAt, cursor.CreatedAt, cursor.ID}, order, reversed
+}
+
+func (p cursorParams) ascending() bool {
+	return (p.Sort == sortOldest) != (p.Before != nil)
+}
+
+func (p cursorParams) mergedKeyset(alias string, repost bool) (string, []interface{}, string, bool) {
+	condition, args, order, reversed := p.keyset(alias, "")
+
+	cursor := p.After
+	if p.Before != nil {
+		cursor = p.Before
+	}
+	if cursor == nil || cursor.Repost == repost {
+		return condition, args, order, reversed
+	}
+
+	ascending := p.ascending()
+	op := "<"
+	if ascending {
+		op = ">"
+	}
+	if ascending == repost {
+		op += "="
+	}
+	return fmt.Sprintf("%s.created_at %s ?", alias, op), []interface{}{cursor.CreatedAt}, order, reversed
+}
+
+func cursorPage[T any](items []T, cursors []listCursor, p cursorParams, reversed bool, total int) ([]T, models.CursorInfo) {
+	hasMore := len(items) > p.Limit
+	if hasMore {
+		items = items[:p.Limit]
+		cursors = cursors[:p.Limit]
+	}
+
+	if reversed {
+		slices.Reverse(items)
+		slices.Reverse(cursors)
+	}
+
+	var first, last listCursor
+	if len(cursors) > 0 {
+		first, last = cursors[0], cursors[len(cursors)-1]
+	}
+
+	return items, newCursorInfo(p, total, hasMore, first, last, len(items))
+}
+
+func newCursorInfo(p cursorParams, total int, hasMore bool, first listCursor, last listCursor, count int) models.CursorInfo {
+	info := models.CursorInfo{
+		Limit:      p.Limit,
+		TotalCount: total,
+		Sort:       p.Sort,
+	}
+
+	if p.Before != nil {
+		info.HasPrev = hasMore
+		info.HasNext = true
+	} else {
+		info.HasNext = hasMore
+		info.HasPrev = p.After != nil
+	}
+
+	if count == 0 {
+		return info
+	}
+
+	if info.HasNext {
+		next := encodeCursor(last)
+		info.NextCursor = &next
+	}
+	if info.HasPrev {
+		prev := encodeCursor(first)
+		info.PrevCursor = &prev
+	}
+	return info
+}
@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	valid := listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 42, Score: 7}
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    *listCursor
+	}{
+		{"round trip", encodeCursor(valid), &valid},
+		{"invalid base64", "not*base64!", nil},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"t":"2026-01-02 03:04:05","id":1}`)), nil},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("hello")), nil},
+		{"missing timestamp", base64.RawURLEncoding.EncodeToString([]byte(`{"id":1}`)), nil},
+		{"zero id", base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2026-01-02 03:04:05","id":0}`)), nil},
+		{"negative id", base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2026-01-02 03:04:05","id":-3}`)), nil},
+		{"wrong field type", base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2026-01-02 03:04:05","id":"1"}`)), nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.encoded)
+			if tt.want == nil {
+				if !errors.Is(err, errInvalidCursor) {
+					t.Fatalf("decodeCursor(%q) error = %v, want errInvalidCursor", tt.encoded, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCursor(%q) error = %v", tt.encoded, err)
+			}
+			if *got != *tt.want {
+				t.Errorf("decodeCursor(%q) = %+v, want %+v", tt.encoded, *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCursorParams(t *testing.T) {
+	cursor := encodeCursor(listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 9})
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+		want    cursorParams
+	}{
+		{name: "defaults", query: "", want: cursorParams{Limit: 20, Sort: sortOldest}},
+		{name: "limit and sort", query: "?limit=5&sort=newest", want: cursorParams{Limit: 5, Sort: sortNewest}},
+		{name: "zero limit", query: "?limit=0", wantErr: true},
+		{name: "limit too large", query: "?limit=51", wantErr: true},
+		{name: "non numeric limit", query: "?limit=ten", wantErr: true},
+		{name: "unknown sort", query: "?sort=top", wantErr: true},
+		{name: "after and before", query: "?after=" + cursor + "&before=" + cursor, wantErr: true},
+		{name: "tampered cursor", query: "?after=" + cursor[:len(cursor)-2], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/"+tt.query, nil)
+			got, err := parseCursorParams(r, 20, sortOldest)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCursorParams(%q) error = nil, want error", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCursorParams(%q) error = %v", tt.query, err)
+			}
+			if got.Limit != tt.want.Limit || got.Sort != tt.want.Sort || got.After != nil || got.Before != nil {
+				t.Errorf("parseCursorParams(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	cursor := &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 9, Score: 4}
+
+	tests := []struct {
+		name         string
+		params       cursorParams
+		wantFilter   string
+		wantArgs     []interface{}
+		wantOrder    string
+		wantReversed bool
+	}{
+		{
+			name:       "first page newest",
+			params:     cursorParams{Sort: sortNewest},
+			wantFilter: "TRUE",
+			wantOrder:  "c.created_at DESC, c.id DESC",
+		},
+		{
+			name:       "after oldest",
+			params:     cursorParams{Sort: sortOldest, After: cursor},
+			wantFilter: "(c.created_at > ? OR (c.created_at = ? AND c.id > ?))",
+			wantArgs:   []interface{}{cursor.CreatedAt, cursor.CreatedAt, cursor.ID},
+			wantOrder:  "c.created_at ASC, c.id ASC",
+		},
+		{
+			name:         "before newest pages backwards",
+			params:       cursorParams{Sort: sortNewest, Before: cursor},
+			wantFilter:   "(c.created_at > ? OR (c.created_at = ? AND c.id > ?))",
+			wantArgs:     []interface{}{cursor.CreatedAt, cursor.CreatedAt, cursor.ID},
+			wantOrder:    "c.created_at ASC, c.id ASC",
+			wantReversed: true,
+		},
+		{
+			name:       "after top",
+			params:     cursorParams{Sort: sortTop, After: cursor},
+			wantFilter: "(c.like_count < ? OR (c.like_count = ? AND c.id < ?))",
+			wantArgs:   []interface{}{cursor.Score, cursor.Score, cursor.ID},
+			wantOrder:  "c.like_count DESC, c.id DESC",
+		},
+		{
+			name:         "before top pages backwards",
+			params:       cursorParams{Sort: sortTop, Before: cursor},
+			wantFilter:   "(c.like_count > ? OR (c.like_count = ? AND c.id > ?))",
+			wantArgs:     []interface{}{cursor.Score, cursor.Score, cursor.ID},
+			wantOrder:    "c.like_count ASC, c.id ASC",
+			wantReversed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, args, order, reversed := tt.params.keyset("c", "c.like_count")
+			if filter != tt.wantFilter {
+				t.Errorf("filter = %q, want %q", filter, tt.wantFilter)
+			}
+			if !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+			if order != tt.wantOrder {
+				t.Errorf("order = %q, want %q", order, tt.wantOrder)
+			}
+			if reversed != tt.wantReversed {
+				t.Errorf("reversed = %v, want %v", reversed, tt.wantReversed)
+			}
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	cursorFor := func(id int) listCursor {
+		return listCursor{CreatedAt: "2026-01-02 03:04:05", ID: id}
+	}
+	cursorsFor := func(ids []int) []listCursor {
+		var cursors []listCursor
+		for _, id := range ids {
+			cursors = append(cursors, cursorFor(id))
+		}
+		return cursors
+	}
+	after := cursorFor(100)
+
+	tests := []struct {
+		name      string
+		items     []int
+		params    cursorParams
+		reversed  bool
+		wantItems []int
+		wantNext  *listCursor
+		wantPrev  *listCursor
+	}{
+		{
+			name:      "first page with more",
+			items:     []int{1, 2, 3},
+			params:    cursorParams{Limit: 2},
+			wantItems: []int{1, 2},
+			wantNext:  &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 2},
+		},
+		{
+			name:      "last page",
+			items:     []int{4, 5},
+			params:    cursorParams{Limit: 2, After: &after},
+			wantItems: []int{4, 5},
+			wantPrev:  &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 4},
+		},
+		{
+			name:      "backward page is reversed",
+			items:     []int{3, 2, 1},
+			params:    cursorParams{Limit: 2, Before: &after},
+			reversed:  true,
+			wantItems: []int{2, 3},
+			wantNext:  &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 3},
+			wantPrev:  &listCursor{CreatedAt: "2026-01-02 03:04:05", ID: 2},
+		},
+		{
+			name:      "empty",
+			params:    cursorParams{Limit: 2},
+			wantItems: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, info := cursorPage(slices.Clone(tt.items), cursorsFor(tt.items), tt.params, tt.reversed, 10)
+			if !slices.Equal(items, tt.wantItems) {
+				t.Fatalf("items = %v, want %v", items, tt.wantItems)
+			}
+			if info.TotalCount != 10 || info.Limit != tt.params.Limit {
+				t.Errorf("info = %+v, want total 10 and limit %d", info, tt.params.Limit)
+			}
+			checkCursor(t, "next", info.NextCursor, tt.wantNext)
+			checkCursor(t, "prev", info.PrevCursor, tt.wantPrev)
+		})
+	}
+}
+
+func checkCursor(t *testing.T, name string, encoded *string, want *listCursor) {
+	t.Helper()
+
+	if want == nil {
+		if encoded != nil {
+			t.Errorf("%s cursor = %q, want none", name, *encoded)
+		}
+		return
+	}
+	if encoded == nil {
+		t.Fatalf("%s cursor = none, want %+v", name, *want)
+	}
+	got, err := decodeCursor(*encoded)
+	if err != nil {
+		t.Fatalf("%s cursor %q: %v", name, *encoded, err)
+	}
+	if *got != *want {
+		t.Errorf("%s cursor = %+v, want %+v", name, *got, *want)
+	}
+}
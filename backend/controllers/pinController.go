@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maxPinnedPosts = 3
+
+func PinPost(w http.ResponseWriter, r *http.Request) {
+	setPostPinned(w, r, true)
+}
+
+func UnpinPost(w http.ResponseWriter, r *http.Request) {
+	setPostPinned(w, r, false)
+}
+
+func setPostPinned(w http.ResponseWriter, r *http.Request, pinned bool) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	pinnedQuery := "SELECT id FROM posts WHERE user_id = ? AND pinned_at IS NOT NULL FOR UPDATE"
+	rows, err := tx.QueryContext(ctx, pinnedQuery, userID)
+	if err != nil {
+		log.Printf("setPostPinned: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	pinnedCount := 0
+	alreadyPinned := false
+	for rows.Next() {
+		var pinnedID int
+		if err := rows.Scan(&pinnedID); err != nil {
+			rows.Close()
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		pinnedCount++
+		if pinnedID == postID {
+			alreadyPinned = true
+		}
+	}
+	rows.Close()
+
+	checkQuery := "SELECT p.user_id FROM posts p WHERE p.id = ? AND " + publicPostFilter
+	var postOwnerID int
+	err = tx.QueryRowContext(ctx, checkQuery, postID).Scan(&postOwnerID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if postOwnerID != userID {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	var message string
+	if pinned {
+		message = "Gönderi sabitlendi"
+		if !alreadyPinned {
+			if pinnedCount >= maxPinnedPosts {
+				http.Error(w, fmt.Sprintf("You can pin at most %d posts", maxPinnedPosts), http.StatusConflict)
+				return
+			}
+			_, err = tx.ExecContext(ctx, "UPDATE posts SET pinned_at = NOW() WHERE id = ? AND user_id = ?", postID, userID)
+		}
+	} else {
+		message = "Gönderi sabitlemesi kaldırıldı"
+		if alreadyPinned {
+			_, err = tx.ExecContext(ctx, "UPDATE posts SET pinned_at = NULL WHERE id = ? AND user_id = ?", postID, userID)
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("setPostPinned: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("setPostPinned: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"post_id":   postID,
+			"user_id":   userID,
+			"is_pinned": pinned,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/services"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+const publicPostFilter = "p.deleted_at IS NULL AND p.archived_at IS NULL AND p.is_published = TRUE"
+
+const profileAccessFilter = `(p.user_id = ?
+	OR EXISTS(SELECT 1 FROM users pu WHERE pu.id = p.user_id AND pu.is_private = FALSE)
+	OR EXISTS(SELECT 1 FROM profile_access_requests par WHERE par.owner_id = p.user_id AND par.requester_id = ? AND par.status = 'approved'))`
+
+const (
+	accessStatusNone     = "none"
+	accessStatusPending  = "pending"
+	accessStatusApproved = "approved"
+)
+
+const (
+	commentPolicyEveryone = "everyone"
+	commentPolicyVerified = "verified"
+	commentPolicyNobody   = "nobody"
+)
+
+var errCommentsDisabled = errors.New("Comments are disabled on this post")
+var errCommentsVerifiedOnly = errors.New("Only verified users can comment on this post")
+
+func lookupVisiblePost(ctx context.Context, postID int, viewerID int) (int, error) {
+	query := "SELECT user_id, archived_at IS NULL AND is_published = TRUE FROM posts WHERE id = ? AND deleted_at IS NULL"
+
+	var ownerID int
+	var isPublic bool
+	if err := globals.DB.QueryRowContext(ctx, query, postID).Scan(&ownerID, &isPublic); err != nil {
+		return 0, err
+	}
+
+	if !isPublic && ownerID != viewerID {
+		return 0, sql.ErrNoRows
+	}
+
+	canView, err := canViewProfile(ctx, ownerID, viewerID)
+	if err != nil {
+		return 0, err
+	}
+	if !canView {
+		return 0, sql.ErrNoRows
+	}
+
+	return ownerID, nil
+}
+
+func viewablePostFilter(viewerID int) (string, []interface{}) {
+	blockFilter, blockArgs := notBlockedFilter("p.user_id", viewerID)
+	args := append([]interface{}{viewerID, viewerID}, blockArgs...)
+	return publicPostFilter + " AND " + profileAccessFilter + " AND " + blockFilter, args
+}
+
+func blockedUserIDs(ctx context.Context, userID int) (map[int]bool, error) {
+	query := "SELECT blocked_id FROM user_blocks WHERE blocker_id = ? UNION SELECT blocker_id FROM user_blocks WHERE blocked_id = ?"
+	rows, err := globals.DB.QueryContext(ctx, query, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := make(map[int]bool)
+	for rows.Next() {
+		var blockedID int
+		if err := rows.Scan(&blockedID); err != nil {
+			return nil, err
+		}
+		blocked[blockedID] = true
+	}
+	return blocked, rows.Err()
+}
+
+func notBlockedFilter(userColumn string, viewerID int) (string, []interface{}) {
+	filter := "NOT EXISTS(SELECT 1 FROM user_blocks ub WHERE (ub.blocker_id = ? AND ub.blocked_id = " + userColumn + ") OR (ub.blocker_id = " + userColumn + " AND ub.blocked_id = ?))"
+	return filter, []interface{}{viewerID, viewerID}
+}
+
+func profileAccessStatus(ctx context.Context, ownerID int, viewerID int) (string, error) {
+	if ownerID == viewerID {
+		return accessStatusApproved, nil
+	}
+
+	var status string
+	query := "SELECT status FROM profile_access_requests WHERE owner_id = ? AND requester_id = ?"
+	err := globals.DB.QueryRowContext(ctx, query, ownerID, viewerID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return accessStatusNone, nil
+	}
+	return status, err
+}
+
+func canViewProfile(ctx context.Context, ownerID int, viewerID int) (bool, error) {
+	if ownerID == viewerID {
+		return true, nil
+	}
+
+	blocked, err := services.IsBlocked(ctx, ownerID, viewerID)
+	if err != nil || blocked {
+		return false, err
+	}
+
+	var isPrivate bool
+	if err := globals.DB.QueryRowContext(ctx, "SELECT is_private FROM users WHERE id = ?", ownerID).Scan(&isPrivate); err != nil {
+		return false, err
+	}
+	if !isPrivate {
+		return true, nil
+	}
+
+	status, err := profileAccessStatus(ctx, ownerID, viewerID)
+	if err != nil {
+		return false, err
+	}
+	return status == accessStatusApproved, nil
+}
+
+func isModerator(ctx context.Context, userID int) bool {
+	var moderator bool
+	err := globals.DB.QueryRowContext(ctx, "SELECT is_moderator FROM users WHERE id = ?", userID).Scan(&moderator)
+	return err == nil && moderator
+}
+
+func checkCommentPolicy(ctx context.Context, postID int, postOwnerID int, userID int) error {
+	if postOwnerID == userID {
+		return nil
+	}
+
+	var policy string
+	if err := globals.DB.QueryRowContext(ctx, "SELECT comment_policy FROM posts WHERE id = ?", postID).Scan(&policy); err != nil {
+		return err
+	}
+
+	switch policy {
+	case commentPolicyNobody:
+		return errCommentsDisabled
+	case commentPolicyVerified:
+		var isVerified bool
+		err := globals.DB.QueryRowContext(ctx, "SELECT is_verified FROM users WHERE id = ?", userID).Scan(&isVerified)
+		if err != nil || !isVerified {
+			return errCommentsVerifiedOnly
+		}
+	}
+
+	return nil
+}
+
+func visibleCommentFilter(alias string, postOwnerID int, viewerID int) (string, []interface{}) {
+	if viewerID == 0 {
+		return alias + ".is_hidden = FALSE", nil
+	}
+
+	blockFilter, blockArgs := notBlockedFilter(alias+".user_id", viewerID)
+	if postOwnerID == viewerID {
+		return blockFilter, blockArgs
+	}
+	return "(" + alias + ".is_hidden = FALSE OR " + alias + ".user_id = ?) AND " + blockFilter, append([]interface{}{viewerID}, blockArgs...)
+}
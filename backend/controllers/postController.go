@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -14,6 +17,17 @@ import (
 	"camagru/services"
 )
 
+const maxScheduleAhead = 365 * 24 * time.Hour
+const maxPostMedia = 10
+
+func removeImages(paths []string) {
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("removeImages: %s: %v", path, err)
+		}
+	}
+}
+
 func CreatePost(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -30,24 +44,60 @@ func CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	savedPath, err := services.CreateImage(post.ImageData, post.FilterName)
-	if err != nil {
-		log.Printf("CreatePost: image error: %v", err)
-		http.Error(w, "Bad request", http.StatusBadRequest)
+	var publishAt interface{}
+	var scheduledFor interface{}
+	isPublished := true
+	if post.PublishAt != "" {
+		scheduled, err := time.Parse(time.RFC3339, post.PublishAt)
+		if err != nil {
+			http.Error(w, "publish_at must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		if scheduled.After(time.Now().Add(maxScheduleAhead)) {
+			http.Error(w, "publish_at is too far in the future", http.StatusBadRequest)
+			return
+		}
+		if scheduled.After(time.Now()) {
+			publishAt = scheduled.UTC().Format(time.DateTime)
+			scheduledFor = scheduled.UTC().Format(time.RFC3339)
+			isPublished = false
+		}
+	}
+
+	images := post.Images
+	if len(images) == 0 {
+		images = []models.CreatePostImage{{ImageData: post.ImageData, FilterName: post.FilterName}}
+	}
+	if len(images) > maxPostMedia {
+		http.Error(w, fmt.Sprintf("A post can contain at most %d images", maxPostMedia), http.StatusBadRequest)
 		return
 	}
 
-	query := "INSERT INTO posts (user_id, image_path) VALUES (?, ?)"
+	var savedPaths []string
+	for _, image := range images {
+		savedPath, err := services.CreateImage(image.ImageData, image.FilterName)
+		if err != nil {
+			log.Printf("CreatePost: image error: %v", err)
+			removeImages(savedPaths)
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+		savedPaths = append(savedPaths, savedPath)
+	}
 
-	exec, err := globals.DB.PrepareContext(ctx, query)
+	tx, err := globals.DB.BeginTx(ctx, nil)
 	if err != nil {
-		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		removeImages(savedPaths)
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
 		return
 	}
-	defer exec.Close()
+	defer tx.Rollback()
 
-	response, err := exec.ExecContext(ctx, userID, savedPath)
+	query := "INSERT INTO posts (user_id, image_path, is_published, publish_at) VALUES (?, ?, ?, ?)"
+
+	response, err := tx.ExecContext(ctx, query, userID, savedPaths[0], isPublished, publishAt)
 	if err != nil {
+		removeImages(savedPaths)
 		if errors.Is(err, context.DeadlineExceeded) {
 			http.Error(w, "Timeout", http.StatusInternalServerError)
 			return
@@ -57,24 +107,43 @@ func CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rowsAffected, _ := response.RowsAffected()
-	if rowsAffected == 0 {
-		http.Error(w, "No rows were inserted", http.StatusInternalServerError)
-		return
-	}
-
 	postID, err := response.LastInsertId()
 	if err != nil {
+		removeImages(savedPaths)
 		http.Error(w, "Error getting postID", http.StatusInternalServerError)
 		return
 	}
 
+	mediaQuery := "INSERT INTO post_media (post_id, image_path, position, filter_name) VALUES (?, ?, ?, ?)"
+	for position, savedPath := range savedPaths {
+		var filterName interface{}
+		if images[position].FilterName != "" {
+			filterName = filepath.Base(images[position].FilterName)
+		}
+		if _, err := tx.ExecContext(ctx, mediaQuery, postID, savedPath, position, filterName); err != nil {
+			removeImages(savedPaths)
+			log.Printf("CreatePost: media insert error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		removeImages(savedPaths)
+		log.Printf("CreatePost: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
         "message": "Gönderi oluşturuldu",
         "data": map[string]interface{}{
-			"user_id":  userID,
-			"post_id":  postID,
+			"user_id":      userID,
+			"post_id":      postID,
+			"media_count":  len(savedPaths),
+			"is_scheduled": !isPublished,
+			"publish_at":   scheduledFor,
         },
 	}
 
@@ -106,7 +175,7 @@ func DeletePost(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	checkQuery := "SELECT user_id FROM posts WHERE id = ?"
+	checkQuery := "SELECT user_id FROM posts WHERE id = ? AND deleted_at IS NULL"
 	var postOwnerID int
 	err = globals.DB.QueryRowContext(ctx, checkQuery, postID).Scan(&postOwnerID)
 	if err != nil {
@@ -119,8 +188,15 @@ func DeletePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deleteQuery := "DELETE FROM posts WHERE id = ? AND user_id = ?"
-	exec, err := globals.DB.PrepareContext(ctx, deleteQuery)
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	deleteQuery := "UPDATE posts SET deleted_at = NOW(), pinned_at = NULL WHERE id = ? AND user_id = ? AND deleted_at IS NULL"
+	exec, err := tx.PrepareContext(ctx, deleteQuery)
 	if err != nil {
 		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
 		return
@@ -144,12 +220,25 @@ func DeletePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := removeReposts(ctx, tx, postID); err != nil {
+		log.Printf("DeletePost: repost cleanup error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("DeletePost: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
-		"message": "Gönderi silindi",
+		"message": "Gönderi çöp kutusuna taşındı",
 		"data": map[string]interface{}{
-			"post_id": postID,
-			"user_id": userID,
+			"post_id":        postID,
+			"user_id":        userID,
+			"retention_days": services.TrashRetentionDays(),
 		},
 	}
 
@@ -185,17 +274,26 @@ func CommentPost(w http.ResponseWriter, r *http.Request)  {
 		return
 	}
 
-	checkQuery := "SELECT id, user_id FROM posts WHERE id = ?"
-	var postID int
-	var toUserID int
-	err = globals.DB.QueryRowContext(ctx, checkQuery, comment.PostID).Scan(&postID, &toUserID)
+	toUserID, err := lookupVisiblePost(ctx, comment.PostID, userID)
 	if err != nil {
 		http.Error(w, "Post bulunamadı", http.StatusNotFound)
 		return
 	}
 
+	if err := checkCommentPolicy(ctx, comment.PostID, toUserID, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
 	insertQuery := "INSERT INTO posts_comments (user_id, post_id, comment) VALUES (?, ?, ?)"
-	exec, err := globals.DB.PrepareContext(ctx, insertQuery)
+	exec, err := tx.PrepareContext(ctx, insertQuery)
 	if err != nil {
 		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
 		return
@@ -225,33 +323,31 @@ func CommentPost(w http.ResponseWriter, r *http.Request)  {
 		return
 	}
 
-	if userID != toUserID {
-		emailQuery := "SELECT username, email, notifications, is_verified FROM users WHERE id = ?"
-		var isVerified bool
-		var isNotifications bool
-		var toUsername string
-		var toEmail string
-		err = globals.DB.QueryRowContext(ctx, emailQuery, toUserID).Scan(&toUsername, &toEmail, &isNotifications, &isVerified)
+	if err := refreshPostCommentCount(ctx, tx, comment.PostID); err != nil {
+		log.Printf("CommentPost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
-		if isVerified && isNotifications {
-			var fromUsername string
-			err = globals.DB.QueryRowContext(ctx, "SELECT username FROM users WHERE id = ?", userID).Scan(&fromUsername)
+	mentions, mentioned, err := storeCommentMentions(ctx, tx, int(commentID), comment.Comment)
+	if err != nil {
+		log.Printf("CommentPost: mention error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
-			notifications := models.NotificationEmail{
-				ToUsername:   toUsername,
-				FromUserID:   int64(userID),
-				FromUsername: fromUsername,
-				EmailType:    models.EmailTypePostCommented,
-				PostID:       int64(comment.PostID),
-			}
+	if err := tx.Commit(); err != nil {
+		log.Printf("CommentPost: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
-			if err = services.SendNotificationEmail(toEmail, notifications); err != nil {
-				http.Error(w, "Email not send", http.StatusInternalServerError)
-				return
-			}
-		}
+	if err := services.NotifyUser(ctx, toUserID, userID, models.EmailTypePostCommented, comment.PostID); err != nil {
+		log.Printf("CommentPost: notification error: %v", err)
 	}
 
+	notifyCommentMentions(ctx, comment.PostID, toUserID, userID, mentioned)
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"message": "Yorum eklendi",
@@ -259,6 +355,7 @@ func CommentPost(w http.ResponseWriter, r *http.Request)  {
 			"comment_id": commentID,
 			"post_id":    comment.PostID,
 			"user_id":    userID,
+			"mentions":   mentions,
 		},
 	}
 
@@ -290,42 +387,22 @@ func LikePost(w http.ResponseWriter, r *http.Request)  {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	checkPostQuery := "SELECT id, user_id FROM posts WHERE id = ?"
-	var existingPostID int
-	var toUserID int
-	err = globals.DB.QueryRowContext(ctx, checkPostQuery, postID).Scan(&existingPostID, &toUserID)
+	toUserID, err := lookupVisiblePost(ctx, postID, userID)
 	if err != nil {
 		http.Error(w, "Post not found", http.StatusNotFound)
 		return
 	}
 
-	checkLikeQuery := "SELECT id FROM posts_likes WHERE user_id = ? AND post_id = ?"
-	var likeID int
-	err = globals.DB.QueryRowContext(ctx, checkLikeQuery, userID, postID).Scan(&likeID)
+	message := models.EmailTypePostLiked
+	action := "liked"
 
-	var query string
-	var message models.EmailType
-	var action string
-
-	if err != nil {
-		query = "INSERT INTO posts_likes (user_id, post_id) VALUES (?, ?)"
-		message = models.EmailTypePostLiked
-		action = "liked"
-	} else {
-		query = "DELETE FROM posts_likes WHERE user_id = ? AND post_id = ?"
+	inserted, newLikeCount, err := insertLike(ctx, userID, postID, reactionHeart)
+	if err == nil && !inserted {
 		message = models.EmailTypePostUnLiked
 		action = "unliked"
+		_, newLikeCount, err = deleteLike(ctx, userID, postID)
 	}
 
-	exec, err := globals.DB.PrepareContext(ctx, query)
-	if err != nil {
-		log.Printf("LikePost: db prepare error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	defer exec.Close()
-
-	result, err := exec.ExecContext(ctx, userID, postID)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			http.Error(w, "Timeout", http.StatusInternalServerError)
@@ -336,39 +413,10 @@ func LikePost(w http.ResponseWriter, r *http.Request)  {
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		http.Error(w, "Database Error", http.StatusInternalServerError)
-		return
-	}
-
-	if userID != toUserID {
-		emailQuery := "SELECT username, email, notifications, is_verified FROM users WHERE id = ?"
-		var isVerified bool
-		var isNotifications bool
-		var toUsername string
-		var toEmail string
-		err = globals.DB.QueryRowContext(ctx, emailQuery, toUserID).Scan(&toUsername, &toEmail, &isNotifications, &isVerified)
-
-		if err == nil && isVerified && isNotifications {
-			var fromUsername string
-			globals.DB.QueryRowContext(ctx, "SELECT username FROM users WHERE id = ?", userID).Scan(&fromUsername)
-
-			notifications := models.NotificationEmail{
-				ToUsername:   toUsername,
-				FromUserID:   int64(userID),
-				FromUsername: fromUsername,
-				EmailType:    message,
-			}
-
-			services.SendNotificationEmail(toEmail, notifications)
-		}
+	if err := services.NotifyUser(ctx, toUserID, userID, message, postID); err != nil {
+		log.Printf("LikePost: notification error: %v", err)
 	}
 
-	var newLikeCount int
-	countQuery := "SELECT COUNT(*) FROM posts_likes WHERE post_id = ?"
-	globals.DB.QueryRowContext(ctx, countQuery, postID).Scan(&newLikeCount)
-
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"message": "Beğeni bildirimi gönderildi",
@@ -408,28 +456,37 @@ func DeleteComment(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	checkQuery := "SELECT user_id FROM posts_comments WHERE id = ?"
+	checkQuery := "SELECT c.user_id, c.post_id, p.user_id FROM posts_comments c JOIN posts p ON c.post_id = p.id WHERE c.id = ?"
 	var commentOwnerID int
-	err = globals.DB.QueryRowContext(ctx, checkQuery, commentID).Scan(&commentOwnerID)
+	var postID int
+	var postOwnerID int
+	err = globals.DB.QueryRowContext(ctx, checkQuery, commentID).Scan(&commentOwnerID, &postID, &postOwnerID)
 	if err != nil {
 		http.Error(w, "Comment not found", http.StatusNotFound)
 		return
 	}
 
-	if commentOwnerID != userID {
+	if commentOwnerID != userID && postOwnerID != userID && !isModerator(ctx, userID) {
 		http.Error(w, "Unauthorized", http.StatusForbidden)
 		return
 	}
 
-	deleteQuery := "DELETE FROM posts_comments WHERE id = ? AND user_id = ?"
-	exec, err := globals.DB.PrepareContext(ctx, deleteQuery)
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	deleteQuery := "DELETE FROM posts_comments WHERE id = ?"
+	exec, err := tx.PrepareContext(ctx, deleteQuery)
 	if err != nil {
 		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
 		return
 	}
 	defer exec.Close()
 
-	result, err := exec.ExecContext(ctx, commentID, userID)
+	result, err := exec.ExecContext(ctx, commentID)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			http.Error(w, "Timeout", http.StatusInternalServerError)
@@ -446,6 +503,18 @@ func DeleteComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := refreshPostCommentCount(ctx, tx, postID); err != nil {
+		log.Printf("DeleteComment: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("DeleteComment: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	jsonResponse := map[string]interface{}{
 		"success": true,
 		"message": "Yorum silindi",
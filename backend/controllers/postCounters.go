@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+)
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func refreshPostLikeCount(ctx context.Context, db execer, postID int) error {
+	query := "UPDATE posts SET like_count = (SELECT COUNT(*) FROM posts_likes WHERE post_id = ?) WHERE id = ?"
+	_, err := db.ExecContext(ctx, query, postID, postID)
+	return err
+}
+
+func refreshPostCommentCount(ctx context.Context, db execer, postID int) error {
+	query := "UPDATE posts SET comment_count = (SELECT COUNT(*) FROM posts_comments WHERE post_id = ?) WHERE id = ?"
+	_, err := db.ExecContext(ctx, query, postID, postID)
+	return err
+}
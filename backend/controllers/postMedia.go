@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"context"
+	"strings"
+)
+
+func loadPostMedia(ctx context.Context, postIDs []int) (map[int][]models.PostMediaDTO, error) {
+	media := make(map[int][]models.PostMediaDTO)
+	if len(postIDs) == 0 {
+		return media, nil
+	}
+
+	placeholders, args := inClause(postIDs)
+	query := "SELECT post_id, image_path, position FROM post_media WHERE post_id IN (" + placeholders + ") ORDER BY post_id, position"
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID int
+		var item models.PostMediaDTO
+		if err := rows.Scan(&postID, &item.ImagePath, &item.Position); err != nil {
+			return nil, err
+		}
+		media[postID] = append(media[postID], item)
+	}
+
+	return media, rows.Err()
+}
+
+func attachPostMedia(ctx context.Context, posts []models.PostDTO) error {
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	media, err := loadPostMedia(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].Media = mediaOrFallback(media[posts[i].ID], posts[i].ImagePath)
+	}
+	return nil
+}
+
+func attachFeedPostMedia(ctx context.Context, posts []models.FeedPostDTO) error {
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	media, err := loadPostMedia(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].Media = mediaOrFallback(media[posts[i].ID], posts[i].ImagePath)
+	}
+	return nil
+}
+
+func mediaOrFallback(media []models.PostMediaDTO, imagePath string) []models.PostMediaDTO {
+	if len(media) > 0 {
+		return media
+	}
+	return []models.PostMediaDTO{{ImagePath: imagePath, Position: 0}}
+}
+
+func inClause(ids []int) (string, []interface{}) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return placeholders, args
+}
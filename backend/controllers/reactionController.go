@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	reactionHeart     = "heart"
+	reactionFire      = "fire"
+	reactionStar      = "star"
+	reactionSmile     = "smile"
+	reactionCool      = "cool"
+	reactionThumbsUp  = "thumbs-up"
+	reactionLightning = "lightning"
+	reactionCamera    = "camera"
+)
+
+var reactionTypes = []string{
+	reactionHeart,
+	reactionFire,
+	reactionStar,
+	reactionSmile,
+	reactionCool,
+	reactionThumbsUp,
+	reactionLightning,
+	reactionCamera,
+}
+
+func isValidReaction(reaction string) bool {
+	for _, t := range reactionTypes {
+		if t == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+func SetReaction(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	if !isValidReaction(req.Reaction) {
+		http.Error(w, "Reaction must be one of "+strings.Join(reactionTypes, ", "), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	toUserID, err := lookupVisiblePost(ctx, postID, userID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Transaction Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	upsertQuery := "INSERT INTO posts_likes (user_id, post_id, reaction) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE reaction = VALUES(reaction)"
+	result, err := tx.ExecContext(ctx, upsertQuery, userID, postID, req.Reaction)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SetReaction: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 1 {
+		if err := refreshPostLikeCount(ctx, tx, postID); err != nil {
+			log.Printf("SetReaction: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("SetReaction: commit error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected == 1 {
+		if err := services.NotifyUser(ctx, toUserID, userID, models.EmailTypePostLiked, postID); err != nil {
+			log.Printf("SetReaction: notification error: %v", err)
+		}
+	}
+
+	writeReactionResponse(w, ctx, postID, userID, &req.Reaction)
+}
+
+func RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := lookupVisiblePost(ctx, postID, userID); err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if _, _, err := deleteLike(ctx, userID, postID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("RemoveReaction: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeReactionResponse(w, ctx, postID, userID, nil)
+}
+
+func writeReactionResponse(w http.ResponseWriter, ctx context.Context, postID int, userID int, reaction *string) {
+	counts, err := loadReactionCounts(ctx, []int{postID})
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	likeCount := 0
+	for _, count := range counts[postID] {
+		likeCount += count
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"post_id":         postID,
+			"user_id":         userID,
+			"viewer_reaction": reaction,
+			"reaction_counts": counts[postID],
+			"like_count":      likeCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func loadReactionCounts(ctx context.Context, postIDs []int) (map[int]map[string]int, error) {
+	counts := make(map[int]map[string]int)
+	for _, id := range postIDs {
+		counts[id] = make(map[string]int)
+		for _, t := range reactionTypes {
+			counts[id][t] = 0
+		}
+	}
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders, args := inClause(postIDs)
+	query := "SELECT post_id, reaction, COUNT(*) FROM posts_likes WHERE post_id IN (" + placeholders + ") GROUP BY post_id, reaction"
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, count int
+		var reaction string
+		if err := rows.Scan(&postID, &reaction, &count); err != nil {
+			return nil, err
+		}
+		counts[postID][reaction] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func attachFeedReactions(ctx context.Context, posts []models.FeedPostDTO, viewerID int) error {
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	counts, err := loadReactionCounts(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	viewerReactions := make(map[int]string)
+	if viewerID != 0 && len(postIDs) > 0 {
+		placeholders, args := inClause(postIDs)
+		query := "SELECT post_id, reaction FROM posts_likes WHERE user_id = ? AND post_id IN (" + placeholders + ")"
+		rows, err := globals.DB.QueryContext(ctx, query, append([]interface{}{viewerID}, args...)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var postID int
+			var reaction string
+			if err := rows.Scan(&postID, &reaction); err != nil {
+				return err
+			}
+			viewerReactions[postID] = reaction
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for i := range posts {
+		posts[i].ReactionCounts = counts[posts[i].ID]
+		if reaction, ok := viewerReactions[posts[i].ID]; ok {
+			posts[i].ViewerReaction = &reaction
+		}
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxRepostCommentary = 255
+
+type repostColumns struct {
+	ID         *int
+	UserID     *int
+	Username   *string
+	Commentary *string
+	CreatedAt  *string
+}
+
+func (c repostColumns) dto() *models.RepostDTO {
+	if c.ID == nil || c.UserID == nil || c.Username == nil || c.CreatedAt == nil {
+		return nil
+	}
+	return &models.RepostDTO{
+		ID:         *c.ID,
+		UserID:     *c.UserID,
+		Username:   *c.Username,
+		Commentary: c.Commentary,
+		CreatedAt:  *c.CreatedAt,
+	}
+}
+
+func removeReposts(ctx context.Context, db execer, postID int) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM reposts WHERE post_id = ?", postID)
+	return err
+}
+
+func removeUserReposts(ctx context.Context, db execer, authorID int) error {
+	_, err := db.ExecContext(ctx, "DELETE r FROM reposts r JOIN posts p ON p.id = r.post_id WHERE p.user_id = ?", authorID)
+	return err
+}
+
+func RepostPost(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.RepostRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad input", http.StatusBadRequest)
+			return
+		}
+	}
+
+	req.Commentary = strings.TrimSpace(req.Commentary)
+	if len(req.Commentary) > maxRepostCommentary {
+		http.Error(w, "Too long commentary", http.StatusBadRequest)
+		return
+	}
+
+	var commentary *string
+	if req.Commentary != "" {
+		commentary = &req.Commentary
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	authorID, err := lookupVisiblePost(ctx, postID, userID)
+	if err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if authorID == userID {
+		http.Error(w, "You cannot repost your own post", http.StatusBadRequest)
+		return
+	}
+
+	var isPrivate bool
+	err = globals.DB.QueryRowContext(ctx, "SELECT is_private FROM users WHERE id = ?", authorID).Scan(&isPrivate)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if isPrivate {
+		http.Error(w, "Posts from private accounts cannot be reposted", http.StatusForbidden)
+		return
+	}
+
+	query := "INSERT INTO reposts (user_id, post_id, commentary) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE commentary = VALUES(commentary)"
+	result, err := globals.DB.ExecContext(ctx, query, userID, postID, commentary)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("RepostPost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 1 {
+		if err := services.NotifyUser(ctx, authorID, userID, models.EmailTypePostReposted, postID); err != nil {
+			log.Printf("RepostPost: notification error: %v", err)
+		}
+	}
+
+	writeRepostResponse(w, ctx, postID, userID, true, "Gönderi paylaşıldı")
+}
+
+func UnrepostPost(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := globals.DB.ExecContext(ctx, "DELETE FROM reposts WHERE user_id = ? AND post_id = ?", userID, postID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("UnrepostPost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeRepostResponse(w, ctx, postID, userID, false, "Paylaşım kaldırıldı")
+}
+
+func writeRepostResponse(w http.ResponseWriter, ctx context.Context, postID int, userID int, reposted bool, message string) {
+	var repostCount int
+	err := globals.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM reposts WHERE post_id = ?", postID).Scan(&repostCount)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"post_id":      postID,
+			"user_id":      userID,
+			"is_reposted":  reposted,
+			"repost_count": repostCount,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetUserReposts(w http.ResponseWriter, r *http.Request) {
+	viewerID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	username := r.PathValue("username")
+
+	page, limit, offset := parsePagination(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var reposterID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE username = ?", username).Scan(&reposterID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	canView, err := canViewProfile(ctx, reposterID, viewerID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if !canView {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	visibleFilter, visibleArgs := viewablePostFilter(viewerID)
+	filter := "r.user_id = ? AND " + visibleFilter
+	filterArgs := append([]interface{}{reposterID}, visibleArgs...)
+
+	var totalPosts int
+	countQuery := "SELECT COUNT(*) FROM reposts r JOIN posts p ON p.id = r.post_id WHERE " + filter
+	err = globals.DB.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&totalPosts)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := `
+		SELECT
+			p.id,
+			p.user_id,
+			u.username,
+			p.image_path,
+			p.like_count,
+			p.comment_count,
+			(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+			EXISTS(SELECT 1 FROM posts_likes WHERE post_id = p.id AND user_id = ?) as is_liked,
+			EXISTS(SELECT 1 FROM posts_saves WHERE post_id = p.id AND user_id = ?) as is_saved,
+			p.created_at,
+			r.id,
+			r.user_id,
+			ru.username,
+			r.commentary,
+			r.created_at
+		FROM reposts r
+		JOIN posts p ON p.id = r.post_id
+		JOIN users u ON p.user_id = u.id
+		JOIN users ru ON ru.id = r.user_id
+		WHERE ` + filter + `
+		ORDER BY r.created_at DESC, r.id DESC
+		LIMIT ? OFFSET ?
+	`
+	args := append([]interface{}{viewerID, viewerID}, filterArgs...)
+	args = append(args, limit, offset)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var posts []models.FeedPostDTO
+	for rows.Next() {
+		var post models.FeedPostDTO
+		var repost repostColumns
+		if err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Username,
+			&post.ImagePath,
+			&post.LikeCount,
+			&post.CommentCount,
+			&post.RepostCount,
+			&post.IsLiked,
+			&post.IsSaved,
+			&post.CreatedAt,
+			&repost.ID,
+			&repost.UserID,
+			&repost.Username,
+			&repost.Commentary,
+			&repost.CreatedAt,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		post.Repost = repost.dto()
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedReactions(ctx, posts, viewerID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"posts":      posts,
+			"pagination": newPaginationInfo(page, limit, totalPosts),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,467 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func SavePost(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SavePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := lookupVisiblePost(ctx, postID, userID); err != nil {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return
+	}
+
+	if req.CollectionID != nil {
+		var ownerID int
+		err = globals.DB.QueryRowContext(ctx, "SELECT user_id FROM saved_collections WHERE id = ?", *req.CollectionID).Scan(&ownerID)
+		if err != nil || ownerID != userID {
+			http.Error(w, "Collection not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	query := "INSERT INTO posts_saves (user_id, post_id, collection_id) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE collection_id = VALUES(collection_id)"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	if _, err := exec.ExecContext(ctx, userID, postID, req.CollectionID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SavePost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Gönderi kaydedildi",
+		"data": map[string]interface{}{
+			"post_id":       postID,
+			"collection_id": req.CollectionID,
+			"is_saved":      true,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func UnsavePost(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	query := "DELETE FROM posts_saves WHERE user_id = ? AND post_id = ?"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	if _, err := exec.ExecContext(ctx, userID, postID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("UnsavePost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Gönderi kaydedilenlerden çıkarıldı",
+		"data": map[string]interface{}{
+			"post_id":  postID,
+			"is_saved": false,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetSavedPosts(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page, limit, offset := parsePagination(r)
+
+	visibleFilter, visibleArgs := viewablePostFilter(userID)
+	filter := "s.user_id = ? AND " + visibleFilter
+	filterArgs := append([]interface{}{userID}, visibleArgs...)
+
+	if collectionStr := r.URL.Query().Get("collection_id"); collectionStr != "" {
+		collectionID, err := strconv.Atoi(collectionStr)
+		if err != nil {
+			http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+			return
+		}
+		filter += " AND s.collection_id = ?"
+		filterArgs = append(filterArgs, collectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var totalPosts int
+	countQuery := "SELECT COUNT(*) FROM posts_saves s JOIN posts p ON s.post_id = p.id WHERE " + filter
+	err = globals.DB.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&totalPosts)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := `
+		SELECT
+			p.id,
+			p.user_id,
+			u.username,
+			p.image_path,
+			p.like_count,
+			p.comment_count,
+			(SELECT COUNT(*) FROM reposts WHERE post_id = p.id) as repost_count,
+			EXISTS(SELECT 1 FROM posts_likes WHERE post_id = p.id AND user_id = ?) as is_liked,
+			p.created_at
+		FROM posts_saves s
+		JOIN posts p ON s.post_id = p.id
+		JOIN users u ON p.user_id = u.id
+		WHERE ` + filter + `
+		ORDER BY s.created_at DESC, s.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	args := append([]interface{}{userID}, filterArgs...)
+	args = append(args, limit, offset)
+
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var posts []models.FeedPostDTO
+	for rows.Next() {
+		post := models.FeedPostDTO{IsSaved: true}
+		if err := rows.Scan(
+			&post.ID,
+			&post.UserID,
+			&post.Username,
+			&post.ImagePath,
+			&post.LikeCount,
+			&post.CommentCount,
+			&post.RepostCount,
+			&post.IsLiked,
+			&post.CreatedAt,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedPostMedia(ctx, posts); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := attachFeedReactions(ctx, posts, userID); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"posts":      posts,
+			"pagination": newPaginationInfo(page, limit, totalPosts),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func GetCollections(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	visibleFilter, visibleArgs := viewablePostFilter(userID)
+
+	query := `
+		SELECT
+			c.id,
+			c.name,
+			(SELECT COUNT(*) FROM posts_saves s JOIN posts p ON s.post_id = p.id WHERE s.collection_id = c.id AND ` + visibleFilter + `) as post_count,
+			c.created_at
+		FROM saved_collections c
+		WHERE c.user_id = ?
+		ORDER BY c.name ASC
+	`
+	args := append(visibleArgs, userID)
+	rows, err := globals.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var collections []models.SavedCollectionDTO
+	for rows.Next() {
+		var collection models.SavedCollectionDTO
+		if err := rows.Scan(&collection.ID, &collection.Name, &collection.PostCount, &collection.CreatedAt); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"collections": collections,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad input", http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) == 0 || len(req.Name) > 50 {
+		http.Error(w, "Collection name must be between 1 and 50 characters", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var existingID int
+	err = globals.DB.QueryRowContext(ctx, "SELECT id FROM saved_collections WHERE user_id = ? AND name = ?", userID, req.Name).Scan(&existingID)
+	if err == nil {
+		http.Error(w, "This collection already exists", http.StatusConflict)
+		return
+	}
+	if err != sql.ErrNoRows {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	query := "INSERT INTO saved_collections (user_id, name) VALUES (?, ?)"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	result, err := exec.ExecContext(ctx, userID, req.Name)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("CreateCollection: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	collectionID, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error getting collectionID", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Koleksiyon oluşturuldu",
+		"data": map[string]interface{}{
+			"collection_id": collectionID,
+			"name":          req.Name,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBytes)
+}
+
+func DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collectionIDstr := r.PathValue("collection_id")
+	collectionID, err := strconv.Atoi(collectionIDstr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	query := "DELETE FROM saved_collections WHERE id = ? AND user_id = ?"
+	exec, err := globals.DB.PrepareContext(ctx, query)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	result, err := exec.ExecContext(ctx, collectionID, userID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("DeleteCollection: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Koleksiyon silindi",
+		"data": map[string]interface{}{
+			"collection_id": collectionID,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
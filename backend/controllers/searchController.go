@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const maxSearchQueryLength = 30
+
+func SearchUsers(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" || len(query) > maxSearchQueryLength {
+		http.Error(w, "Query must be between 1 and 30 characters", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, offset := parsePagination(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	exclude, err := blockedUserIDs(ctx, userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	matches := services.SearchUsers(query, exclude)
+	total := len(matches)
+
+	users := []models.UserSearchResultDTO{}
+	for i := offset; i < total && i < offset+limit; i++ {
+		match := matches[i]
+		users = append(users, models.UserSearchResultDTO{
+			UserID:       match.ID,
+			Username:     match.Username,
+			PostCount:    match.PostCount,
+			LastActiveAt: match.LastActiveAt,
+			MatchType:    match.MatchType,
+		})
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"users":      users,
+			"query":      query,
+			"pagination": newPaginationInfo(page, limit, total),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
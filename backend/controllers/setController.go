@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"camagru/globals"
+	"camagru/models"
 	"camagru/services"
 	"context"
 	"database/sql"
@@ -426,3 +427,77 @@ func SetPassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseBytes)
 }
+
+func SetPrivacy(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.SetPrivacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.IsPrivate == nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET is_private = ? WHERE id = ?", *req.IsPrivate, userID); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("SetPrivacy: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	message := "Hesap gizli yapıldı"
+	if *req.IsPrivate {
+		if err := removeUserReposts(ctx, tx, userID); err != nil {
+			log.Printf("SetPrivacy: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		message = "Hesap herkese açık yapıldı"
+		if _, err := tx.ExecContext(ctx, "DELETE FROM profile_access_requests WHERE owner_id = ? AND status = 'pending'", userID); err != nil {
+			log.Printf("SetPrivacy: db error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data": map[string]interface{}{
+			"user_id":    userID,
+			"is_private": *req.IsPrivate,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
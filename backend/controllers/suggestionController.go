@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultSuggestionCount = 10
+	maxSuggestionCount     = 30
+	suggestionSourceLimit  = 50
+)
+
+const (
+	reasonEngagedWithPosts = "engaged_with_their_posts"
+	reasonSimilarTaste     = "similar_taste"
+	reasonPopularCreator   = "popular_creator"
+)
+
+type suggestionSource struct {
+	Reason string
+	Weight int
+	Query  string
+	Args   []interface{}
+}
+
+type suggestionCandidate struct {
+	Score        int
+	Reason       string
+	reasonScores map[string]int
+}
+
+func suggestionCandidateFilter(column string, viewerID int) (string, []interface{}) {
+	blockFilter, blockArgs := notBlockedFilter(column, viewerID)
+	filter := column + ` <> ?
+		AND NOT EXISTS(SELECT 1 FROM follows f WHERE f.follower_id = ? AND f.following_id = ` + column + `)
+		AND NOT EXISTS(SELECT 1 FROM user_mutes um WHERE um.muter_id = ? AND um.muted_id = ` + column + `)
+		AND ` + blockFilter
+	return filter, append([]interface{}{viewerID, viewerID, viewerID}, blockArgs...)
+}
+
+func suggestionSources(viewerID int) []suggestionSource {
+	authorFilter, authorArgs := suggestionCandidateFilter("p.user_id", viewerID)
+	otherFilter, otherArgs := suggestionCandidateFilter("other.user_id", viewerID)
+	visibleFilter, visibleArgs := viewablePostFilter(viewerID)
+
+	sharedPosts := `
+		SELECT post_id FROM posts_likes WHERE user_id = ?
+		UNION
+		SELECT post_id FROM posts_comments WHERE user_id = ?
+	`
+	sharedArgs := append([]interface{}{viewerID, viewerID}, visibleArgs...)
+	similarArgs := append(append([]interface{}{}, sharedArgs...), sharedArgs...)
+	similarArgs = append(append(similarArgs, otherArgs...), suggestionSourceLimit)
+
+	return []suggestionSource{
+		{
+			Reason: reasonEngagedWithPosts,
+			Weight: 3,
+			Query: `
+				SELECT p.user_id, COUNT(*) as engagement
+				FROM (
+					SELECT post_id FROM posts_likes WHERE user_id = ?
+					UNION ALL
+					SELECT post_id FROM posts_comments WHERE user_id = ?
+				) mine
+				JOIN posts p ON p.id = mine.post_id
+				WHERE ` + publicPostFilter + ` AND ` + authorFilter + `
+				GROUP BY p.user_id
+				ORDER BY engagement DESC
+				LIMIT ?
+			`,
+			Args: append(append([]interface{}{viewerID, viewerID}, authorArgs...), suggestionSourceLimit),
+		},
+		{
+			Reason: reasonSimilarTaste,
+			Weight: 2,
+			Query: `
+				SELECT other.user_id, COUNT(DISTINCT other.post_id) as engagement
+				FROM (
+					SELECT l.user_id, l.post_id
+					FROM (` + sharedPosts + `) mine
+					JOIN posts p ON p.id = mine.post_id
+					JOIN posts_likes l ON l.post_id = mine.post_id
+					WHERE ` + visibleFilter + `
+					UNION ALL
+					SELECT c.user_id, c.post_id
+					FROM (` + sharedPosts + `) mine
+					JOIN posts p ON p.id = mine.post_id
+					JOIN posts_comments c ON c.post_id = mine.post_id
+					WHERE ` + visibleFilter + `
+				) other
+				WHERE ` + otherFilter + `
+				GROUP BY other.user_id
+				ORDER BY engagement DESC
+				LIMIT ?
+			`,
+			Args: similarArgs,
+		},
+		{
+			Reason: reasonPopularCreator,
+			Weight: 1,
+			Query: `
+				SELECT p.user_id, COUNT(l.id) as engagement
+				FROM posts p
+				JOIN posts_likes l ON l.post_id = p.id
+				WHERE ` + publicPostFilter + `
+					AND p.created_at >= DATE_SUB(NOW(), INTERVAL 30 DAY)
+					AND ` + authorFilter + `
+				GROUP BY p.user_id
+				ORDER BY engagement DESC
+				LIMIT ?
+			`,
+			Args: append(append([]interface{}{}, authorArgs...), suggestionSourceLimit),
+		},
+	}
+}
+
+func GetSuggestedUsers(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := defaultSuggestionCount
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > maxSuggestionCount {
+			http.Error(w, "limit must be between 1 and 30", http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	candidates := make(map[int]*suggestionCandidate)
+	for _, source := range suggestionSources(userID) {
+		if err := collectSuggestions(ctx, source, candidates); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	ids := make([]int, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := candidates[ids[i]], candidates[ids[j]]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	suggestions := []models.SuggestedUserDTO{}
+	if len(ids) > 0 {
+		placeholders, idArgs := inClause(ids)
+		query := `
+			SELECT
+				u.id,
+				u.username,
+				(SELECT COUNT(*) FROM posts p WHERE p.user_id = u.id AND ` + publicPostFilter + `) as post_count
+			FROM users u
+			WHERE u.id IN (` + placeholders + `)
+		`
+		rows, err := globals.DB.QueryContext(ctx, query, idArgs...)
+		if err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		defer rows.Close()
+
+		users := make(map[int]models.SuggestedUserDTO)
+		for rows.Next() {
+			var user models.SuggestedUserDTO
+			if err := rows.Scan(&user.UserID, &user.Username, &user.PostCount); err != nil {
+				http.Error(w, "DB Error", http.StatusInternalServerError)
+				return
+			}
+			users[user.UserID] = user
+		}
+
+		if err := rows.Err(); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+
+		for _, id := range ids {
+			user, ok := users[id]
+			if !ok {
+				continue
+			}
+			user.Reason = candidates[id].Reason
+			user.Score = candidates[id].Score
+			suggestions = append(suggestions, user)
+		}
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"users": suggestions,
+			"count": len(suggestions),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func collectSuggestions(ctx context.Context, source suggestionSource, candidates map[int]*suggestionCandidate) error {
+	rows, err := globals.DB.QueryContext(ctx, source.Query, source.Args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var candidateID, engagement int
+		if err := rows.Scan(&candidateID, &engagement); err != nil {
+			return err
+		}
+
+		candidate, ok := candidates[candidateID]
+		if !ok {
+			candidate = &suggestionCandidate{reasonScores: make(map[string]int)}
+			candidates[candidateID] = candidate
+		}
+
+		score := engagement * source.Weight
+		candidate.Score += score
+		candidate.reasonScores[source.Reason] += score
+		if candidate.reasonScores[source.Reason] > candidate.reasonScores[candidate.Reason] {
+			candidate.Reason = source.Reason
+		}
+	}
+
+	return rows.Err()
+}
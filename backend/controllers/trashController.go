@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"camagru/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func GetTrash(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT
+			p.id,
+			p.image_path,
+			p.like_count,
+			p.comment_count,
+			p.created_at,
+			p.deleted_at,
+			DATE_ADD(p.deleted_at, INTERVAL ? DAY) as purge_at
+		FROM posts p
+		WHERE p.user_id = ? AND p.deleted_at IS NOT NULL
+		ORDER BY p.deleted_at DESC
+	`
+	rows, err := globals.DB.QueryContext(ctx, query, services.TrashRetentionDays(), userID)
+	if err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var posts []models.TrashPostDTO
+	for rows.Next() {
+		var post models.TrashPostDTO
+		if err := rows.Scan(
+			&post.ID,
+			&post.ImagePath,
+			&post.LikeCount,
+			&post.CommentCount,
+			&post.CreatedAt,
+			&post.DeletedAt,
+			&post.PurgeAt,
+		); err != nil {
+			http.Error(w, "DB Error", http.StatusInternalServerError)
+			return
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		http.Error(w, "DB Error", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"posts":          posts,
+			"retention_days": services.TrashRetentionDays(),
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func RestorePost(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.GetUserIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	postIDstr := r.PathValue("post_id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	checkQuery := "SELECT user_id FROM posts WHERE id = ? AND deleted_at IS NOT NULL"
+	var postOwnerID int
+	err = globals.DB.QueryRowContext(ctx, checkQuery, postID).Scan(&postOwnerID)
+	if err != nil {
+		http.Error(w, "Post not found in trash", http.StatusNotFound)
+		return
+	}
+
+	if postOwnerID != userID {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	restoreQuery := "UPDATE posts SET deleted_at = NULL WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL"
+	exec, err := globals.DB.PrepareContext(ctx, restoreQuery)
+	if err != nil {
+		http.Error(w, "DB Prepare Error", http.StatusInternalServerError)
+		return
+	}
+	defer exec.Close()
+
+	result, err := exec.ExecContext(ctx, postID, userID)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "Timeout", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("RestorePost: db error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		http.Error(w, "Post could not be restored", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse := map[string]interface{}{
+		"success": true,
+		"message": "Gönderi geri yüklendi",
+		"data": map[string]interface{}{
+			"post_id": postID,
+			"user_id": userID,
+		},
+	}
+
+	responseBytes, err := json.Marshal(jsonResponse)
+	if err != nil {
+		http.Error(w, "JSON cant create", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -2,16 +2,28 @@ package globals
 
 import (
 	"database/sql"
-	_ "github.com/go-sql-driver/mysql"
 	"fmt"
 	"log"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 var DB *sql.DB
 
 func InitDB(dsn string) error {
-	var err error
-	DB, err = sql.Open("mysql", dsn)
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+
+	cfg.Loc = time.UTC
+	if cfg.Params == nil {
+		cfg.Params = make(map[string]string)
+	}
+	cfg.Params["time_zone"] = "'+00:00'"
+
+	DB, err = sql.Open("mysql", cfg.FormatDSN())
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
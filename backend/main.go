@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -33,7 +34,7 @@ func securityHeadersMiddleware(next http.Handler) http.Handler {
 func corsMiddleware(allowedOrigin string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if r.Method == "OPTIONS" {
@@ -61,12 +62,24 @@ func main() {
 
 	services.InitJWT()
 	services.ValidateEmailConfig()
+	services.InitTrash()
+	services.InitComments()
 
 	if err := globals.InitDB(dsn); err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
 	defer globals.CloseDB()
 
+	if err := services.RunMigrations(); err != nil {
+		log.Fatalf("failed to run migrations: %v", err)
+	}
+
+	services.StartTrashPurger(1 * time.Hour)
+	services.StartPublishScheduler(1 * time.Minute)
+	services.StartUserSearchIndexer(1 * time.Minute)
+	services.StartTrendingScorer(5 * time.Minute)
+	services.StartChallengeCloser(1 * time.Minute)
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("POST /api/register", controllers.Register)
@@ -77,11 +90,75 @@ func main() {
 	mux.HandleFunc("POST /api/comment/post", controllers.CommentPost)
 	mux.HandleFunc("DELETE /api/delete/comment/{comment_id}", controllers.DeleteComment)
 	mux.HandleFunc("POST /api/like/post/{post_id}", controllers.LikePost)
+	mux.HandleFunc("PUT /api/posts/{post_id}/like", controllers.PutLike)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/like", controllers.DeleteLike)
+	mux.HandleFunc("GET /api/posts/{post_id}/likes", controllers.GetPostLikes)
+	mux.HandleFunc("PUT /api/posts/{post_id}/reaction", controllers.SetReaction)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/reaction", controllers.RemoveReaction)
+	mux.HandleFunc("POST /api/comments/{comment_id}/replies", controllers.ReplyComment)
+	mux.HandleFunc("GET /api/comments/{comment_id}/replies", controllers.GetCommentReplies)
+	mux.HandleFunc("PATCH /api/comments/{comment_id}", controllers.EditComment)
+	mux.HandleFunc("POST /api/comments/{comment_id}/like", controllers.LikeComment)
+	mux.HandleFunc("GET /api/comments/{comment_id}/revisions", controllers.GetCommentRevisions)
+	mux.HandleFunc("POST /api/comments/{comment_id}/hide", controllers.HideComment)
+	mux.HandleFunc("DELETE /api/comments/{comment_id}/hide", controllers.UnhideComment)
+	mux.HandleFunc("PATCH /api/posts/{post_id}/comment-policy", controllers.SetCommentPolicy)
+
+	mux.HandleFunc("GET /api/trash", controllers.GetTrash)
+	mux.HandleFunc("POST /api/posts/{post_id}/restore", controllers.RestorePost)
+	mux.HandleFunc("POST /api/posts/{post_id}/archive", controllers.ArchivePost)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/archive", controllers.UnarchivePost)
+	mux.HandleFunc("POST /api/posts/{post_id}/pin", controllers.PinPost)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/pin", controllers.UnpinPost)
+	mux.HandleFunc("POST /api/posts/{post_id}/save", controllers.SavePost)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/save", controllers.UnsavePost)
+	mux.HandleFunc("POST /api/posts/{post_id}/repost", controllers.RepostPost)
+	mux.HandleFunc("DELETE /api/posts/{post_id}/repost", controllers.UnrepostPost)
+	mux.HandleFunc("GET /api/users/{username}/reposts", controllers.GetUserReposts)
+	mux.HandleFunc("GET /api/me/saved", controllers.GetSavedPosts)
+	mux.HandleFunc("GET /api/me/collections", controllers.GetCollections)
+	mux.HandleFunc("POST /api/me/collections", controllers.CreateCollection)
+	mux.HandleFunc("DELETE /api/me/collections/{collection_id}", controllers.DeleteCollection)
+
+	mux.HandleFunc("POST /api/users/{username}/follow", controllers.FollowUser)
+	mux.HandleFunc("DELETE /api/users/{username}/follow", controllers.UnfollowUser)
+	mux.HandleFunc("GET /api/users/{username}/followers", controllers.GetFollowers)
+	mux.HandleFunc("GET /api/users/{username}/following", controllers.GetFollowing)
+	mux.HandleFunc("POST /api/users/{username}/access-request", controllers.RequestAccess)
+	mux.HandleFunc("DELETE /api/users/{username}/access-request", controllers.CancelAccessRequest)
+	mux.HandleFunc("GET /api/me/access-requests", controllers.GetAccessRequests)
+	mux.HandleFunc("POST /api/me/access-requests/{request_id}/approve", controllers.ApproveAccessRequest)
+	mux.HandleFunc("POST /api/me/access-requests/{request_id}/deny", controllers.DenyAccessRequest)
+	mux.HandleFunc("DELETE /api/me/access-requests/{request_id}", controllers.RevokeAccess)
+	mux.HandleFunc("POST /api/users/{username}/block", controllers.BlockUser)
+	mux.HandleFunc("DELETE /api/users/{username}/block", controllers.UnblockUser)
+	mux.HandleFunc("POST /api/users/{username}/mute", controllers.MuteUser)
+	mux.HandleFunc("DELETE /api/users/{username}/mute", controllers.UnmuteUser)
+	mux.HandleFunc("GET /api/me/blocked", controllers.GetBlockedUsers)
+	mux.HandleFunc("GET /api/me/muted", controllers.GetMutedUsers)
+	mux.HandleFunc("GET /api/search/users", controllers.SearchUsers)
+	mux.HandleFunc("GET /api/suggestions/users", controllers.GetSuggestedUsers)
+	mux.HandleFunc("GET /api/mentions/suggestions", controllers.GetMentionSuggestions)
+
+	mux.HandleFunc("GET /api/conversations", controllers.GetConversations)
+	mux.HandleFunc("POST /api/conversations", controllers.StartConversation)
+	mux.HandleFunc("GET /api/conversations/{conversation_id}/messages", controllers.GetConversationMessages)
+	mux.HandleFunc("POST /api/conversations/{conversation_id}/messages", controllers.SendMessage)
+	mux.HandleFunc("POST /api/conversations/{conversation_id}/read", controllers.MarkConversationRead)
+	mux.HandleFunc("GET /api/me/unread-messages", controllers.GetUnreadMessageCount)
+
+	mux.HandleFunc("GET /api/challenges", controllers.GetChallenges)
+	mux.HandleFunc("POST /api/challenges", controllers.CreateChallenge)
+	mux.HandleFunc("GET /api/challenges/{challenge_id}", controllers.GetChallenge)
+	mux.HandleFunc("GET /api/challenges/{challenge_id}/entries", controllers.GetChallengeEntries)
+	mux.HandleFunc("POST /api/challenges/{challenge_id}/entries", controllers.SubmitChallengeEntry)
+	mux.HandleFunc("DELETE /api/challenges/{challenge_id}/entries", controllers.WithdrawChallengeEntry)
 
 	mux.HandleFunc("PATCH /api/set/username", controllers.SetUsername)
 	mux.HandleFunc("PATCH /api/set/email", controllers.SetEmail)
 	mux.HandleFunc("PATCH /api/set/password", controllers.SetPassword)
 	mux.HandleFunc("PATCH /api/set/notifications", controllers.SetNotifications)
+	mux.HandleFunc("PATCH /api/set/privacy", controllers.SetPrivacy)
 	mux.HandleFunc("POST /api/forgot-password", controllers.ForgotPassword)
 	mux.HandleFunc("POST /api/reset-password", controllers.ResetPassword)
 
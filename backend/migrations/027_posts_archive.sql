@@ -0,0 +1,2 @@
+ALTER TABLE posts
+    ADD COLUMN archived_at DATETIME NULL;
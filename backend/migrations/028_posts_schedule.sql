@@ -0,0 +1,4 @@
+ALTER TABLE posts
+    ADD COLUMN is_published BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN publish_at DATETIME NULL,
+    ADD INDEX idx_posts_schedule (is_published, publish_at);
@@ -0,0 +1,2 @@
+ALTER TABLE posts
+    ADD COLUMN pinned_at DATETIME NULL;
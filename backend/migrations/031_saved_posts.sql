@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS saved_collections (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_saved_collections_name (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS posts_saves (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    collection_id BIGINT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (collection_id) REFERENCES saved_collections(id) ON DELETE SET NULL,
+    UNIQUE KEY uq_posts_saves_user_post (user_id, post_id),
+    INDEX idx_posts_saves_user_created (user_id, created_at)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE posts_comments
+    ADD COLUMN parent_id BIGINT UNSIGNED NULL,
+    ADD COLUMN depth TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    ADD FOREIGN KEY (parent_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    ADD INDEX idx_posts_comments_parent (parent_id, created_at);
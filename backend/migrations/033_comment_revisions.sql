@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN is_moderator BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE posts_comments
+    ADD COLUMN edited_at DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS posts_comments_revisions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    comment VARCHAR(255) NOT NULL,
+    edited_by BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (edited_by) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_posts_comments_revisions_comment (comment_id, created_at)
+);
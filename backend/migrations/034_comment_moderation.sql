@@ -0,0 +1,5 @@
+ALTER TABLE posts
+    ADD COLUMN comment_policy ENUM('everyone', 'verified', 'nobody') NOT NULL DEFAULT 'everyone';
+
+ALTER TABLE posts_comments
+    ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
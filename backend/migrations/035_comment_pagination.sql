@@ -0,0 +1,4 @@
+ALTER TABLE posts_comments
+    DROP INDEX idx_posts_comments_parent,
+    ADD INDEX idx_posts_comments_parent (parent_id, created_at, id),
+    ADD INDEX idx_posts_comments_post (post_id, parent_id, created_at, id);
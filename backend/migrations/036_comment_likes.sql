@@ -0,0 +1,13 @@
+ALTER TABLE posts_comments
+    ADD COLUMN like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD INDEX idx_posts_comments_top (post_id, parent_id, like_count, id);
+
+CREATE TABLE IF NOT EXISTS comments_likes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_comments_likes_user_comment (user_id, comment_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE posts_likes
+    ADD COLUMN reaction ENUM('heart', 'fire', 'star', 'smile', 'cool', 'thumbs-up', 'lightning', 'camera') NOT NULL DEFAULT 'heart';
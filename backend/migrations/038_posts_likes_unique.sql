@@ -0,0 +1,8 @@
+DELETE newer FROM posts_likes newer
+JOIN posts_likes older
+    ON older.user_id = newer.user_id
+    AND older.post_id = newer.post_id
+    AND older.id < newer.id;
+
+ALTER TABLE posts_likes
+    ADD UNIQUE KEY uq_posts_likes_user_post (user_id, post_id);
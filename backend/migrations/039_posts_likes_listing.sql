@@ -0,0 +1,2 @@
+ALTER TABLE posts_likes
+    ADD INDEX idx_posts_likes_post_created (post_id, created_at, id);
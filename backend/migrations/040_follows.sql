@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS follows (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    follower_id BIGINT UNSIGNED NOT NULL,
+    following_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (follower_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (following_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_follows_follower_following (follower_id, following_id),
+    INDEX idx_follows_follower_created (follower_id, created_at, id),
+    INDEX idx_follows_following_created (following_id, created_at, id)
+);
@@ -0,0 +1,14 @@
+ALTER TABLE posts
+    ADD INDEX idx_posts_created (created_at, id),
+    ADD INDEX idx_posts_user_created (user_id, created_at, id);
+
+ALTER TABLE posts
+    ADD COLUMN like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN comment_count INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD INDEX idx_posts_like_count (like_count, created_at, id),
+    ADD INDEX idx_posts_comment_count (comment_count, created_at, id);
+
+UPDATE posts p
+SET
+    p.like_count = (SELECT COUNT(*) FROM posts_likes WHERE post_id = p.id),
+    p.comment_count = (SELECT COUNT(*) FROM posts_comments WHERE post_id = p.id);
@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS profile_access_requests (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    owner_id BIGINT UNSIGNED NOT NULL,
+    requester_id BIGINT UNSIGNED NOT NULL,
+    status ENUM('pending', 'approved') NOT NULL DEFAULT 'pending',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    responded_at DATETIME NULL,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_profile_access_owner_requester (owner_id, requester_id),
+    INDEX idx_profile_access_owner_status (owner_id, status, created_at, id)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_blocks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    blocker_id BIGINT UNSIGNED NOT NULL,
+    blocked_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_user_blocks_blocker_blocked (blocker_id, blocked_id),
+    INDEX idx_user_blocks_blocked (blocked_id, blocker_id),
+    INDEX idx_user_blocks_blocker_created (blocker_id, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS user_mutes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    muter_id BIGINT UNSIGNED NOT NULL,
+    muted_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (muter_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (muted_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_user_mutes_muter_muted (muter_id, muted_id),
+    INDEX idx_user_mutes_muter_created (muter_id, created_at, id)
+);
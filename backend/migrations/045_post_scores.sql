@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS post_scores (
+    post_id BIGINT UNSIGNED PRIMARY KEY,
+    like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    comment_count INT UNSIGNED NOT NULL DEFAULT 0,
+    score DOUBLE NOT NULL DEFAULT 0,
+    computed_at DATETIME NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    INDEX idx_post_scores_score (score, post_id),
+    INDEX idx_post_scores_computed (computed_at)
+);
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS conversations (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_a_id BIGINT UNSIGNED NOT NULL,
+    user_b_id BIGINT UNSIGNED NOT NULL,
+    last_message_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_a_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_b_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_conversations_users (user_a_id, user_b_id),
+    INDEX idx_conversations_user_b (user_b_id)
+);
+
+CREATE TABLE IF NOT EXISTS conversation_participants (
+    conversation_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    last_read_message_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (conversation_id, user_id),
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_conversation_participants_user (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    conversation_id BIGINT UNSIGNED NOT NULL,
+    sender_id BIGINT UNSIGNED NOT NULL,
+    body VARCHAR(1000) NOT NULL DEFAULT '',
+    shared_post_id BIGINT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (shared_post_id) REFERENCES posts(id) ON DELETE SET NULL,
+    INDEX idx_messages_conversation_created (conversation_id, created_at, id),
+    INDEX idx_messages_conversation_id (conversation_id, id)
+);
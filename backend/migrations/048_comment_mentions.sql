@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    mention_offset INT UNSIGNED NOT NULL,
+    mention_length INT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_comment_mentions_comment_offset (comment_id, mention_offset),
+    INDEX idx_comment_mentions_user (user_id, created_at)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reposts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    commentary VARCHAR(255) NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_reposts_user_post (user_id, post_id),
+    INDEX idx_reposts_post (post_id),
+    INDEX idx_reposts_created (created_at, id),
+    INDEX idx_reposts_user_created (user_id, created_at, id)
+);
@@ -0,0 +1,41 @@
+ALTER TABLE post_media ADD COLUMN filter_name VARCHAR(64) NULL;
+
+CREATE TABLE IF NOT EXISTS challenges (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    closed_at DATETIME NULL,
+    winner_post_id BIGINT UNSIGNED NULL,
+    winner_user_id BIGINT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (winner_post_id) REFERENCES posts(id) ON DELETE SET NULL,
+    FOREIGN KEY (winner_user_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_challenges_window (starts_at, ends_at),
+    INDEX idx_challenges_closing (closed_at, ends_at)
+);
+
+CREATE TABLE IF NOT EXISTS challenge_stickers (
+    challenge_id BIGINT UNSIGNED NOT NULL,
+    filter_name VARCHAR(64) NOT NULL,
+    PRIMARY KEY (challenge_id, filter_name),
+    FOREIGN KEY (challenge_id) REFERENCES challenges(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS challenge_entries (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    challenge_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    vote_count INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (challenge_id) REFERENCES challenges(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_challenge_entries_user (challenge_id, user_id),
+    UNIQUE KEY uq_challenge_entries_post (challenge_id, post_id),
+    INDEX idx_challenge_entries_created (challenge_id, created_at, id)
+);
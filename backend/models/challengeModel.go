@@ -0,0 +1,37 @@
+package models
+
+type CreateChallengeRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Stickers    []string `json:"stickers"`
+	StartsAt    string   `json:"starts_at"`
+	EndsAt      string   `json:"ends_at"`
+}
+
+type SubmitChallengeEntryRequest struct {
+	PostID int `json:"post_id"`
+}
+
+type ChallengeDTO struct {
+	ID             int      `json:"id"`
+	Title          string   `json:"title"`
+	Description    *string  `json:"description"`
+	Stickers       []string `json:"stickers"`
+	StartsAt       string   `json:"starts_at"`
+	EndsAt         string   `json:"ends_at"`
+	Status         string   `json:"status"`
+	EntryCount     int      `json:"entry_count"`
+	HasEntered     bool     `json:"has_entered"`
+	WinnerPostID   *int     `json:"winner_post_id"`
+	WinnerUserID   *int     `json:"winner_user_id"`
+	WinnerUsername *string  `json:"winner_username"`
+	ClosedAt       *string  `json:"closed_at"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+type ChallengeEntryDTO struct {
+	FeedPostDTO
+	EntryID   int  `json:"entry_id"`
+	VoteCount int  `json:"vote_count"`
+	IsWinner  bool `json:"is_winner"`
+}
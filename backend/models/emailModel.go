@@ -6,6 +6,13 @@ const (
 	EmailTypePostLiked    EmailType = iota 
 	EmailTypePostUnLiked                  
 	EmailTypePostCommented                 
+	EmailTypeCommentReplied
+	EmailTypeNewFollower
+	EmailTypeAccessRequested
+	EmailTypeAccessApproved
+	EmailTypeCommentMentioned
+	EmailTypePostReposted
+	EmailTypeChallengeWon
 )
 
 func (e EmailType) String() string {
@@ -16,6 +23,20 @@ func (e EmailType) String() string {
 		return "Post Beğenisi Kaldırıldı"
 	case EmailTypePostCommented:
 		return "Yorum Yapıldı"
+	case EmailTypeCommentReplied:
+		return "Yoruma Yanıt Verildi"
+	case EmailTypeNewFollower:
+		return "Yeni Takipçi"
+	case EmailTypeAccessRequested:
+		return "Erişim İsteği"
+	case EmailTypeAccessApproved:
+		return "Erişim İsteği Onaylandı"
+	case EmailTypeCommentMentioned:
+		return "Yorumda Bahsedildi"
+	case EmailTypePostReposted:
+		return "Post Paylaşıldı"
+	case EmailTypeChallengeWon:
+		return "Yarışma Kazanıldı"
 	default:
 		return "Bilinmeyen"
 	}
@@ -29,6 +50,20 @@ func (e EmailType) Subject() string {
 		return "Post Beğenisi Kaldırıldı!"
 	case EmailTypePostCommented:
 		return "Postuna Yorum Yapıldı!"
+	case EmailTypeCommentReplied:
+		return "Yorumuna Yanıt Verildi!"
+	case EmailTypeNewFollower:
+		return "Yeni Bir Takipçin Var!"
+	case EmailTypeAccessRequested:
+		return "Profiline Erişim İstendi!"
+	case EmailTypeAccessApproved:
+		return "Erişim İsteğin Onaylandı!"
+	case EmailTypeCommentMentioned:
+		return "Bir Yorumda Senden Bahsedildi!"
+	case EmailTypePostReposted:
+		return "Postun Paylaşıldı!"
+	case EmailTypeChallengeWon:
+		return "Yarışmayı Kazandın!"
 	default:
 		return "Camagru Bildirimi"
 	}
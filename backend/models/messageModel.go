@@ -0,0 +1,37 @@
+package models
+
+type StartConversationRequest struct {
+	Username string `json:"username"`
+}
+
+type SendMessageRequest struct {
+	Body   string `json:"body"`
+	PostID *int   `json:"post_id"`
+}
+
+type SharedPostDTO struct {
+	ID        int    `json:"id"`
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	ImagePath string `json:"image_path"`
+}
+
+type MessageDTO struct {
+	ID             int            `json:"id"`
+	ConversationID int            `json:"conversation_id"`
+	SenderID       int            `json:"sender_id"`
+	Body           string         `json:"body"`
+	SharedPost     *SharedPostDTO `json:"shared_post"`
+	IsMine         bool           `json:"is_mine"`
+	CreatedAt      string         `json:"created_at"`
+}
+
+type ConversationDTO struct {
+	ID            int     `json:"id"`
+	UserID        int     `json:"user_id"`
+	Username      string  `json:"username"`
+	LastMessage   *string `json:"last_message"`
+	LastMessageAt *string `json:"last_message_at"`
+	UnreadCount   int     `json:"unread_count"`
+	CreatedAt     string  `json:"created_at"`
+}
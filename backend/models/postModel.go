@@ -1,10 +1,22 @@
 package models
 
-type CreatePostRequest struct {
+type CreatePostImage struct {
     ImageData  string `json:"image"`
     FilterName string `json:"filter"`
 }
 
+type CreatePostRequest struct {
+    ImageData  string            `json:"image"`
+    FilterName string            `json:"filter"`
+    Images     []CreatePostImage `json:"images"`
+    PublishAt  string            `json:"publish_at"`
+}
+
+type PostMediaDTO struct {
+	ImagePath string `json:"image_path"`
+	Position  int    `json:"position"`
+}
+
 type CreateComment struct {
 	PostID 	int		`json:"postid" binding:"required"`
 	Comment string	`json:"comment" binding:"required"`
@@ -13,10 +25,30 @@ type CreateComment struct {
 type PostDTO struct {
 	ID           int    `json:"id"`
 	UserID       int    `json:"user_id"`
+	ImagePath    string         `json:"image_path"`
+	Media        []PostMediaDTO `json:"media"`
+	LikeCount    int    `json:"like_count"`
+	CommentCount int    `json:"comment_count"`
+	RepostCount  int    `json:"repost_count"`
+	IsArchived   bool    `json:"is_archived"`
+	IsScheduled  bool    `json:"is_scheduled"`
+	IsPinned     bool    `json:"is_pinned"`
+	PublishAt    *string `json:"publish_at,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+type TrashPostDTO struct {
+	ID           int    `json:"id"`
 	ImagePath    string `json:"image_path"`
 	LikeCount    int    `json:"like_count"`
 	CommentCount int    `json:"comment_count"`
 	CreatedAt    string `json:"created_at"`
+	DeletedAt    string `json:"deleted_at"`
+	PurgeAt      string `json:"purge_at"`
+}
+
+type CreateReply struct {
+	Comment string	`json:"comment" binding:"required"`
 }
 
 type PostCommentsDTO struct {
@@ -24,20 +56,105 @@ type PostCommentsDTO struct {
 	UserID		int		`json:"user_id"`
 	Username	string	`json:"username"`
 	Comment		string	`json:"comment"`
+	ParentID	*int	`json:"parent_id"`
+	ReplyCount	int		`json:"reply_count"`
 	CreatedAt 	string 	`json:"created_at"`
+	EditedAt	*string	`json:"edited_at"`
+	IsHidden	bool	`json:"is_hidden"`
+	LikeCount	int		`json:"like_count"`
+	IsLiked		bool	`json:"is_liked"`
+	Mentions	[]CommentMentionDTO	`json:"mentions"`
+}
+
+type CommentMentionDTO struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+type CommentPolicyRequest struct {
+	Policy string `json:"policy"`
+}
+
+type EditComment struct {
+	Comment string	`json:"comment" binding:"required"`
+}
+
+type CommentRevisionDTO struct {
+	ID			int		`json:"id"`
+	Comment		string	`json:"comment"`
+	EditedBy	int		`json:"edited_by"`
+	Username	string	`json:"username"`
+	CreatedAt	string	`json:"created_at"`
 }
 
 type FeedPostDTO struct {
 	ID           int    `json:"id"`
 	UserID       int    `json:"user_id"`
-	Username     string `json:"username"`
-	ImagePath    string `json:"image_path"`
+	Username     string         `json:"username"`
+	ImagePath    string         `json:"image_path"`
+	Media        []PostMediaDTO `json:"media"`
 	LikeCount    int    `json:"like_count"`
 	CommentCount int    `json:"comment_count"`
+	RepostCount  int    `json:"repost_count"`
 	IsLiked      bool   `json:"is_liked"`
+	IsSaved      bool   `json:"is_saved"`
+	ReactionCounts map[string]int `json:"reaction_counts"`
+	ViewerReaction *string        `json:"viewer_reaction"`
+	Repost       *RepostDTO `json:"repost"`
 	CreatedAt    string `json:"created_at"`
 }
 
+type RepostDTO struct {
+	ID         int     `json:"id"`
+	UserID     int     `json:"user_id"`
+	Username   string  `json:"username"`
+	Commentary *string `json:"commentary"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type RepostRequest struct {
+	Commentary string `json:"commentary"`
+}
+
+type PostLikerDTO struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Reaction string `json:"reaction"`
+	LikedAt  string `json:"liked_at"`
+	IsViewer bool   `json:"is_viewer"`
+}
+
+type SetReactionRequest struct {
+	Reaction string `json:"reaction"`
+}
+
+type SavePostRequest struct {
+	CollectionID *int `json:"collection_id"`
+}
+
+type CreateCollectionRequest struct {
+	Name string `json:"name"`
+}
+
+type SavedCollectionDTO struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CursorInfo struct {
+	Limit      int     `json:"limit"`
+	Sort       string  `json:"sort"`
+	TotalCount int     `json:"total_count"`
+	NextCursor *string `json:"next_cursor"`
+	PrevCursor *string `json:"prev_cursor"`
+	HasNext    bool    `json:"has_next"`
+	HasPrev    bool    `json:"has_prev"`
+}
+
 type PaginationInfo struct {
 	CurrentPage int  `json:"current_page"`
 	TotalPages  int  `json:"total_pages"`
@@ -12,10 +12,58 @@ type UserDTO struct {
 	Email	    		string 	`json:"email"`
 	Notifications    	bool 	`json:"notifications"`
 	IsVerified	    	bool 	`json:"is_verified"`
+	IsPrivate	    	bool 	`json:"is_private"`
 	CreatedAt			string	`json:"created_at"`
 }
 
 type LoginDTO struct {
 	Username	string	`json:"username" binding:"required"`
 	Password	string	`json:"password" binding:"required"`
-}
\ No newline at end of file
+}
+type FollowUserDTO struct {
+	UserID      int    `json:"user_id"`
+	Username    string `json:"username"`
+	FollowedAt  string `json:"followed_at"`
+	IsFollowing bool   `json:"is_following"`
+}
+
+type SetPrivacyRequest struct {
+	IsPrivate *bool `json:"is_private"`
+}
+
+type AccessRequestDTO struct {
+	ID          int     `json:"id"`
+	UserID      int     `json:"user_id"`
+	Username    string  `json:"username"`
+	Status      string  `json:"status"`
+	CreatedAt   string  `json:"created_at"`
+	RespondedAt *string `json:"responded_at"`
+}
+
+type UserRelationDTO struct {
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	CreatedAt string `json:"created_at"`
+}
+
+type UserSearchResultDTO struct {
+	UserID       int    `json:"user_id"`
+	Username     string `json:"username"`
+	PostCount    int    `json:"post_count"`
+	LastActiveAt string `json:"last_active_at"`
+	MatchType    string `json:"match_type"`
+}
+
+type SuggestedUserDTO struct {
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	PostCount int    `json:"post_count"`
+	Reason    string `json:"reason"`
+	Score     int    `json:"score"`
+}
+
+type MentionSuggestionDTO struct {
+	UserID             int    `json:"user_id"`
+	Username           string `json:"username"`
+	RecentlyInteracted bool   `json:"recently_interacted"`
+}
@@ -1,5 +1,35 @@
 CREATE DATABASE IF NOT EXISTS camagru;
 
+CREATE TABLE IF NOT EXISTS camagru.schema_migrations (
+    version VARCHAR(100) PRIMARY KEY,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT IGNORE INTO camagru.schema_migrations (version) VALUES
+    ('026_posts_trash'),
+    ('027_posts_archive'),
+    ('028_posts_schedule'),
+    ('029_post_media'),
+    ('030_posts_pin'),
+    ('031_saved_posts'),
+    ('032_comment_replies'),
+    ('033_comment_revisions'),
+    ('034_comment_moderation'),
+    ('035_comment_pagination'),
+    ('036_comment_likes'),
+    ('037_post_reactions'),
+    ('038_posts_likes_unique'),
+    ('039_posts_likes_listing'),
+    ('040_follows'),
+    ('041_feed_filters'),
+    ('042_private_accounts'),
+    ('043_blocks_mutes'),
+    ('045_post_scores'),
+    ('047_direct_messages'),
+    ('048_comment_mentions'),
+    ('049_reposts'),
+    ('050_challenges');
+
 CREATE TABLE IF NOT EXISTS camagru.users (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     username VARCHAR(50) NOT NULL UNIQUE,
@@ -7,6 +37,8 @@ CREATE TABLE IF NOT EXISTS camagru.users (
     password_hash VARCHAR(255) NOT NULL,
     notifications BOOLEAN NOT NULL DEFAULT TRUE,
     is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    is_moderator BOOLEAN NOT NULL DEFAULT FALSE,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
     verification_token VARCHAR(255) DEFAULT NULL,
     reset_token VARCHAR(255) DEFAULT NULL,
     reset_token_expiry DATETIME NULL,
@@ -18,24 +50,265 @@ CREATE TABLE IF NOT EXISTS camagru.posts (
     user_id BIGINT UNSIGNED NOT NULL,
     image_path VARCHAR(255) NOT NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    deleted_at DATETIME NULL,
+    archived_at DATETIME NULL,
+    is_published BOOLEAN NOT NULL DEFAULT TRUE,
+    publish_at DATETIME NULL,
+    pinned_at DATETIME NULL,
+    comment_policy ENUM('everyone', 'verified', 'nobody') NOT NULL DEFAULT 'everyone',
+    like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    comment_count INT UNSIGNED NOT NULL DEFAULT 0,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_posts_deleted_at (deleted_at),
+    INDEX idx_posts_schedule (is_published, publish_at),
+    INDEX idx_posts_created (created_at, id),
+    INDEX idx_posts_user_created (user_id, created_at, id),
+    INDEX idx_posts_like_count (like_count, created_at, id),
+    INDEX idx_posts_comment_count (comment_count, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.post_media (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    post_id BIGINT UNSIGNED NOT NULL,
+    image_path VARCHAR(255) NOT NULL,
+    position TINYINT UNSIGNED NOT NULL,
+    filter_name VARCHAR(64) NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_post_media_position (post_id, position)
 );
 
 CREATE TABLE IF NOT EXISTS camagru.posts_comments (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     user_id BIGINT UNSIGNED NOT NULL,
     post_id BIGINT UNSIGNED NOT NULL,
+    parent_id BIGINT UNSIGNED NULL,
+    depth TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    comment VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    edited_at DATETIME NULL,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    INDEX idx_posts_comments_parent (parent_id, created_at, id),
+    INDEX idx_posts_comments_post (post_id, parent_id, created_at, id),
+    INDEX idx_posts_comments_top (post_id, parent_id, like_count, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.comments_likes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_comments_likes_user_comment (user_id, comment_id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.posts_comments_revisions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    comment_id BIGINT UNSIGNED NOT NULL,
     comment VARCHAR(255) NOT NULL,
+    edited_by BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (edited_by) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_posts_comments_revisions_comment (comment_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.saved_collections (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_saved_collections_name (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.posts_saves (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    collection_id BIGINT UNSIGNED NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
-    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (collection_id) REFERENCES saved_collections(id) ON DELETE SET NULL,
+    UNIQUE KEY uq_posts_saves_user_post (user_id, post_id),
+    INDEX idx_posts_saves_user_created (user_id, created_at)
 );
 
 CREATE TABLE IF NOT EXISTS camagru.posts_likes (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     user_id BIGINT UNSIGNED NOT NULL,
     post_id BIGINT UNSIGNED NOT NULL,
+    reaction ENUM('heart', 'fire', 'star', 'smile', 'cool', 'thumbs-up', 'lightning', 'camera') NOT NULL DEFAULT 'heart',
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
-    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
-);
\ No newline at end of file
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_posts_likes_user_post (user_id, post_id),
+    INDEX idx_posts_likes_post_created (post_id, created_at, id)
+);
+CREATE TABLE IF NOT EXISTS camagru.follows (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    follower_id BIGINT UNSIGNED NOT NULL,
+    following_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (follower_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (following_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_follows_follower_following (follower_id, following_id),
+    INDEX idx_follows_follower_created (follower_id, created_at, id),
+    INDEX idx_follows_following_created (following_id, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.profile_access_requests (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    owner_id BIGINT UNSIGNED NOT NULL,
+    requester_id BIGINT UNSIGNED NOT NULL,
+    status ENUM('pending', 'approved') NOT NULL DEFAULT 'pending',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    responded_at DATETIME NULL,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_profile_access_owner_requester (owner_id, requester_id),
+    INDEX idx_profile_access_owner_status (owner_id, status, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.user_blocks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    blocker_id BIGINT UNSIGNED NOT NULL,
+    blocked_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_user_blocks_blocker_blocked (blocker_id, blocked_id),
+    INDEX idx_user_blocks_blocked (blocked_id, blocker_id),
+    INDEX idx_user_blocks_blocker_created (blocker_id, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.user_mutes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    muter_id BIGINT UNSIGNED NOT NULL,
+    muted_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (muter_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (muted_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_user_mutes_muter_muted (muter_id, muted_id),
+    INDEX idx_user_mutes_muter_created (muter_id, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.post_scores (
+    post_id BIGINT UNSIGNED PRIMARY KEY,
+    like_count INT UNSIGNED NOT NULL DEFAULT 0,
+    comment_count INT UNSIGNED NOT NULL DEFAULT 0,
+    score DOUBLE NOT NULL DEFAULT 0,
+    computed_at DATETIME NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    INDEX idx_post_scores_score (score, post_id),
+    INDEX idx_post_scores_computed (computed_at)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.conversations (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_a_id BIGINT UNSIGNED NOT NULL,
+    user_b_id BIGINT UNSIGNED NOT NULL,
+    last_message_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_a_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_b_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_conversations_users (user_a_id, user_b_id),
+    INDEX idx_conversations_user_b (user_b_id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.conversation_participants (
+    conversation_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    last_read_message_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (conversation_id, user_id),
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_conversation_participants_user (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.messages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    conversation_id BIGINT UNSIGNED NOT NULL,
+    sender_id BIGINT UNSIGNED NOT NULL,
+    body VARCHAR(1000) NOT NULL DEFAULT '',
+    shared_post_id BIGINT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (shared_post_id) REFERENCES posts(id) ON DELETE SET NULL,
+    INDEX idx_messages_conversation_created (conversation_id, created_at, id),
+    INDEX idx_messages_conversation_id (conversation_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.comment_mentions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    mention_offset INT UNSIGNED NOT NULL,
+    mention_length INT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (comment_id) REFERENCES posts_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_comment_mentions_comment_offset (comment_id, mention_offset),
+    INDEX idx_comment_mentions_user (user_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.reposts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    commentary VARCHAR(255) NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_reposts_user_post (user_id, post_id),
+    INDEX idx_reposts_post (post_id),
+    INDEX idx_reposts_created (created_at, id),
+    INDEX idx_reposts_user_created (user_id, created_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.challenges (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    closed_at DATETIME NULL,
+    winner_post_id BIGINT UNSIGNED NULL,
+    winner_user_id BIGINT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (winner_post_id) REFERENCES posts(id) ON DELETE SET NULL,
+    FOREIGN KEY (winner_user_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_challenges_window (starts_at, ends_at),
+    INDEX idx_challenges_closing (closed_at, ends_at)
+);
+
+CREATE TABLE IF NOT EXISTS camagru.challenge_stickers (
+    challenge_id BIGINT UNSIGNED NOT NULL,
+    filter_name VARCHAR(64) NOT NULL,
+    PRIMARY KEY (challenge_id, filter_name),
+    FOREIGN KEY (challenge_id) REFERENCES challenges(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS camagru.challenge_entries (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    challenge_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    vote_count INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (challenge_id) REFERENCES challenges(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_challenge_entries_user (challenge_id, user_id),
+    UNIQUE KEY uq_challenge_entries_post (challenge_id, post_id),
+    INDEX idx_challenge_entries_created (challenge_id, created_at, id)
+);
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"camagru/globals"
+	"context"
+)
+
+func IsBlocked(ctx context.Context, userID int, otherUserID int) (bool, error) {
+	if userID == 0 || otherUserID == 0 || userID == otherUserID {
+		return false, nil
+	}
+
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)
+		)
+	`
+	var blocked bool
+	err := globals.DB.QueryRowContext(ctx, query, userID, otherUserID, otherUserID, userID).Scan(&blocked)
+	return blocked, err
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+func ChallengeVoteCount(entryAlias string, challengeAlias string) string {
+	return fmt.Sprintf(`
+		(SELECT COUNT(*) FROM posts_likes l
+		WHERE l.post_id = %[1]s.post_id AND l.user_id <> %[1]s.user_id
+			AND l.created_at >= %[2]s.starts_at AND l.created_at < %[2]s.ends_at)
+	`, entryAlias, challengeAlias)
+}
+
+func StartChallengeCloser(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			closed, err := CloseDueChallenges()
+			if err != nil {
+				log.Printf("ChallengeCloser: %v", err)
+			} else if closed > 0 {
+				log.Printf("ChallengeCloser: closed %d challenges", closed)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func CloseDueChallenges() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, err := globals.DB.QueryContext(ctx, "SELECT id FROM challenges WHERE closed_at IS NULL AND ends_at <= NOW()")
+	if err != nil {
+		return 0, err
+	}
+
+	var due []int
+	for rows.Next() {
+		var challengeID int
+		if err := rows.Scan(&challengeID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, challengeID)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, challengeID := range due {
+		winnerPostID, winnerUserID, err := closeChallenge(ctx, challengeID)
+		if err != nil {
+			return closed, err
+		}
+		closed++
+
+		if winnerUserID == 0 {
+			continue
+		}
+		if err := NotifyUser(ctx, winnerUserID, SystemSenderID, models.EmailTypeChallengeWon, winnerPostID); err != nil {
+			log.Printf("ChallengeCloser: notification error: %v", err)
+		}
+	}
+
+	return closed, nil
+}
+
+func closeChallenge(ctx context.Context, challengeID int) (int, int, error) {
+	tx, err := globals.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	var locked int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM challenges WHERE id = ? AND closed_at IS NULL FOR UPDATE", challengeID).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tallyQuery := `
+		UPDATE challenge_entries ce
+		JOIN challenges c ON c.id = ce.challenge_id
+		SET ce.vote_count = ` + ChallengeVoteCount("ce", "c") + `
+		WHERE ce.challenge_id = ?
+	`
+	if _, err := tx.ExecContext(ctx, tallyQuery, challengeID); err != nil {
+		return 0, 0, err
+	}
+
+	winnerQuery := `
+		SELECT ce.post_id, ce.user_id
+		FROM challenge_entries ce
+		JOIN posts p ON p.id = ce.post_id
+		WHERE ce.challenge_id = ? AND ce.vote_count > 0
+			AND p.deleted_at IS NULL AND p.archived_at IS NULL AND p.is_published = TRUE
+		ORDER BY ce.vote_count DESC, ce.created_at ASC, ce.id ASC
+		LIMIT 1
+	`
+	var winnerPostID, winnerUserID int
+	err = tx.QueryRowContext(ctx, winnerQuery, challengeID).Scan(&winnerPostID, &winnerUserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, err
+	}
+
+	var postID, userID interface{}
+	if winnerUserID != 0 {
+		postID, userID = winnerPostID, winnerUserID
+	}
+
+	closeQuery := "UPDATE challenges SET closed_at = NOW(), winner_post_id = ?, winner_user_id = ? WHERE id = ?"
+	if _, err := tx.ExecContext(ctx, closeQuery, postID, userID, challengeID); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+
+	return winnerPostID, winnerUserID, nil
+}
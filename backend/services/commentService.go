@@ -0,0 +1,29 @@
+package services
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultCommentEditWindowMinutes = 15
+
+var commentEditWindow = defaultCommentEditWindowMinutes * time.Minute
+
+func InitComments() {
+	val := os.Getenv("COMMENT_EDIT_WINDOW_MINUTES")
+	if val == "" {
+		return
+	}
+
+	minutes, err := strconv.Atoi(val)
+	if err != nil || minutes < 0 {
+		log.Fatalf("invalid COMMENT_EDIT_WINDOW_MINUTES value %q", val)
+	}
+	commentEditWindow = time.Duration(minutes) * time.Minute
+}
+
+func CommentEditWindow() time.Duration {
+	return commentEditWindow
+}
@@ -119,6 +119,48 @@ func generateNotificationBody(notification models.NotificationEmail) string {
 			<p><strong>%s</strong> postuna yorum yaptı.</p>
 		`, toName, fromName)
 
+	case models.EmailTypeCommentReplied:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> yorumuna yanıt verdi.</p>
+		`, toName, fromName)
+
+	case models.EmailTypeNewFollower:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> seni takip etmeye başladı.</p>
+		`, toName, fromName)
+
+	case models.EmailTypeAccessRequested:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> gizli profiline erişim istedi.</p>
+		`, toName, fromName)
+
+	case models.EmailTypeAccessApproved:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> profil erişim isteğini onayladı.</p>
+		`, toName, fromName)
+
+	case models.EmailTypeCommentMentioned:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> bir yorumda senden bahsetti.</p>
+		`, toName, fromName)
+
+	case models.EmailTypePostReposted:
+		content = fmt.Sprintf(`
+			<h2>Merhaba %s!</h2>
+			<p><strong>%s</strong> postunu kendi profilinde paylaştı.</p>
+		`, toName, fromName)
+
+	case models.EmailTypeChallengeWon:
+		content = fmt.Sprintf(`
+			<h2>Tebrikler %s!</h2>
+			<p>Gönderin katıldığın fotoğraf yarışmasını kazandı.</p>
+		`, toName)
+
 	default:
 		content = "<p>Yeni bir bildiriminiz var.</p>"
 	}
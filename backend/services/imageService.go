@@ -18,6 +18,21 @@ import (
 
 const maxImageSize = 5 * 1024 * 1024
 
+var allowedFilters = map[string]bool{
+	"fire.png":      true,
+	"thumbs-up.png": true,
+	"camera.png":    true,
+	"lightning.png": true,
+	"cool.png":      true,
+	"heart.png":     true,
+	"star.png":      true,
+	"smile.png":     true,
+}
+
+func IsValidFilter(filterName string) bool {
+	return allowedFilters[filterName]
+}
+
 func CreateImage(base64Image string, filterName string) (string, error) {
 	parts := strings.Split(base64Image, ",")
 	if len(parts) != 2 {
@@ -56,17 +71,7 @@ func CreateImage(base64Image string, filterName string) (string, error) {
     if filterName != "" {
         filterName = filepath.Base(filterName)
 
-        allowedFilters := map[string]bool{
-            "fire.png":      true,
-            "thumbs-up.png": true,
-            "camera.png":    true,
-            "lightning.png":  true,
-            "cool.png":      true,
-            "heart.png":     true,
-            "star.png":      true,
-            "smile.png":     true,
-        }
-        if !allowedFilters[filterName] {
+        if !IsValidFilter(filterName) {
             return "", fmt.Errorf("Invalid filter name")
         }
 
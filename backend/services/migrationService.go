@@ -0,0 +1,103 @@
+package services
+
+import (
+	"camagru/globals"
+	"camagru/migrations"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	mysqlErrTableExists     = 1050
+	mysqlErrDuplicateColumn = 1060
+	mysqlErrDuplicateKey    = 1061
+)
+
+func RunMigrations() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	createQuery := "CREATE TABLE IF NOT EXISTS schema_migrations (version VARCHAR(100) PRIMARY KEY, applied_at DATETIME DEFAULT CURRENT_TIMESTAMP)"
+	if _, err := globals.DB.ExecContext(ctx, createQuery); err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	names, err := fs.Glob(migrations.Files, "*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(name, ".sql")
+		if applied[version] {
+			continue
+		}
+
+		contents, err := fs.ReadFile(migrations.Files, name)
+		if err != nil {
+			return err
+		}
+
+		for _, statement := range strings.Split(string(contents), ";") {
+			statement = strings.TrimSpace(statement)
+			if statement == "" {
+				continue
+			}
+			if _, err := globals.DB.ExecContext(ctx, statement); err != nil && !isAlreadyApplied(err) {
+				return fmt.Errorf("migration %s: %w", version, err)
+			}
+		}
+
+		if _, err := globals.DB.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+			return err
+		}
+		log.Printf("Migrations: applied %s", version)
+	}
+
+	return nil
+}
+
+func appliedMigrations(ctx context.Context) (map[string]bool, error) {
+	rows, err := globals.DB.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func isAlreadyApplied(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	switch mysqlErr.Number {
+	case mysqlErrTableExists, mysqlErrDuplicateColumn, mysqlErrDuplicateKey:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"camagru/globals"
+	"camagru/models"
+	"context"
+)
+
+const SystemSenderID = 0
+
+const systemSenderName = "Camagru"
+
+func NotifyUser(ctx context.Context, toUserID int, fromUserID int, emailType models.EmailType, postID int) error {
+	if toUserID == fromUserID {
+		return nil
+	}
+
+	blocked, err := IsBlocked(ctx, toUserID, fromUserID)
+	if err != nil || blocked {
+		return err
+	}
+
+	emailQuery := "SELECT username, email, notifications, is_verified FROM users WHERE id = ?"
+	var toUsername string
+	var toEmail string
+	var isNotifications bool
+	var isVerified bool
+	err = globals.DB.QueryRowContext(ctx, emailQuery, toUserID).Scan(&toUsername, &toEmail, &isNotifications, &isVerified)
+	if err != nil {
+		return err
+	}
+
+	if !isVerified || !isNotifications {
+		return nil
+	}
+
+	fromUsername := systemSenderName
+	if fromUserID != SystemSenderID {
+		err = globals.DB.QueryRowContext(ctx, "SELECT username FROM users WHERE id = ?", fromUserID).Scan(&fromUsername)
+		if err != nil {
+			return err
+		}
+	}
+
+	notification := models.NotificationEmail{
+		ToUsername:   toUsername,
+		FromUserID:   int64(fromUserID),
+		FromUsername: fromUsername,
+		EmailType:    emailType,
+		PostID:       int64(postID),
+	}
+
+	return SendNotificationEmail(toEmail, notification)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"camagru/globals"
+	"context"
+	"log"
+	"time"
+)
+
+func StartPublishScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			published, err := PublishDuePosts()
+			if err != nil {
+				log.Printf("PublishScheduler: %v", err)
+			} else if published > 0 {
+				log.Printf("PublishScheduler: published %d posts", published)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func PublishDuePosts() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := "SELECT id FROM posts WHERE is_published = FALSE AND publish_at <= NOW() AND deleted_at IS NULL"
+	rows, err := globals.DB.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	var due []int
+	for rows.Next() {
+		var postID int
+		if err := rows.Scan(&postID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, postID)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, postID := range due {
+		result, err := globals.DB.ExecContext(ctx, "UPDATE posts SET is_published = TRUE, created_at = publish_at WHERE id = ? AND is_published = FALSE", postID)
+		if err != nil {
+			return published, err
+		}
+
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			continue
+		}
+		published++
+	}
+
+	return published, nil
+}
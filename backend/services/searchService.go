@@ -0,0 +1,217 @@
+package services
+
+import (
+	"camagru/globals"
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MatchExact  = "exact"
+	MatchPrefix = "prefix"
+	MatchFuzzy  = "fuzzy"
+)
+
+type UserSearchEntry struct {
+	ID           int
+	Username     string
+	PostCount    int
+	LastActiveAt string
+	key          string
+}
+
+type UserSearchResult struct {
+	UserSearchEntry
+	MatchType string
+	distance  int
+}
+
+var (
+	userIndexMu sync.RWMutex
+	userIndex   []UserSearchEntry
+)
+
+func StartUserSearchIndexer(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := RefreshUserSearchIndex(); err != nil {
+				log.Printf("UserSearchIndexer: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func RefreshUserSearchIndex() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			(SELECT COUNT(*) FROM posts p WHERE p.user_id = u.id AND p.deleted_at IS NULL AND p.archived_at IS NULL AND p.is_published = TRUE) as post_count,
+			GREATEST(
+				u.created_at,
+				COALESCE((SELECT MAX(created_at) FROM posts WHERE user_id = u.id), u.created_at),
+				COALESCE((SELECT MAX(created_at) FROM posts_comments WHERE user_id = u.id), u.created_at),
+				COALESCE((SELECT MAX(created_at) FROM posts_likes WHERE user_id = u.id), u.created_at)
+			) as last_active_at
+		FROM users u
+	`
+	rows, err := globals.DB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var entries []UserSearchEntry
+	for rows.Next() {
+		var entry UserSearchEntry
+		if err := rows.Scan(&entry.ID, &entry.Username, &entry.PostCount, &entry.LastActiveAt); err != nil {
+			return err
+		}
+		entry.key = strings.ToLower(entry.Username)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	userIndexMu.Lock()
+	userIndex = entries
+	userIndexMu.Unlock()
+	return nil
+}
+
+func SearchUsers(query string, exclude map[int]bool) []UserSearchResult {
+	query = strings.ToLower(query)
+	maxDistance := fuzzyTolerance(query)
+
+	userIndexMu.RLock()
+	entries := userIndex
+	userIndexMu.RUnlock()
+
+	var results []UserSearchResult
+	matched := make(map[int]bool)
+
+	start := sort.Search(len(entries), func(i int) bool {
+		return entries[i].key >= query
+	})
+	for i := start; i < len(entries) && strings.HasPrefix(entries[i].key, query); i++ {
+		entry := entries[i]
+		if exclude[entry.ID] {
+			continue
+		}
+		matchType := MatchPrefix
+		if entry.key == query {
+			matchType = MatchExact
+		}
+		results = append(results, UserSearchResult{UserSearchEntry: entry, MatchType: matchType})
+		matched[entry.ID] = true
+	}
+
+	if maxDistance > 0 {
+		for _, entry := range entries {
+			if matched[entry.ID] || exclude[entry.ID] {
+				continue
+			}
+			distance := fuzzyDistance(query, entry.key, maxDistance)
+			if distance <= maxDistance {
+				results = append(results, UserSearchResult{UserSearchEntry: entry, MatchType: MatchFuzzy, distance: distance})
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if a.PostCount != b.PostCount {
+			return a.PostCount > b.PostCount
+		}
+		if a.LastActiveAt != b.LastActiveAt {
+			return a.LastActiveAt > b.LastActiveAt
+		}
+		return a.key < b.key
+	})
+
+	return results
+}
+
+func rank(result UserSearchResult) int {
+	switch result.MatchType {
+	case MatchExact:
+		return 0
+	case MatchPrefix:
+		return 1
+	default:
+		return 1 + result.distance
+	}
+}
+
+func fuzzyTolerance(query string) int {
+	switch n := len([]rune(query)); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func fuzzyDistance(query string, key string, maxDistance int) int {
+	q := []rune(query)
+	k := []rune(key)
+
+	distance := editDistance(q, k)
+	if len(k) > len(q) {
+		if prefixDistance := editDistance(q, k[:len(q)]); prefixDistance < distance {
+			distance = prefixDistance
+		}
+	}
+	if distance > maxDistance {
+		return maxDistance + 1
+	}
+	return distance
+}
+
+func editDistance(a []rune, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"both empty", "", "", 0},
+		{"empty source", "", "abc", 3},
+		{"empty target", "abc", "", 3},
+		{"equal", "alice", "alice", 0},
+		{"substitution", "alice", "alica", 1},
+		{"insertion", "alice", "allice", 1},
+		{"deletion", "alice", "alce", 1},
+		{"adjacent transposition", "alice", "ailce", 1},
+		{"transposition at end", "bob", "bbo", 1},
+		{"two transpositions", "abcd", "badc", 2},
+		{"unrelated", "abc", "xyz", 3},
+		{"multibyte runes", "çağrı", "cağrı", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := editDistance([]rune(tt.b), []rune(tt.a)); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyTolerance(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 0},
+		{"ab", 0},
+		{"abc", 1},
+		{"abcde", 1},
+		{"abcdef", 2},
+		{"çağ", 1},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyTolerance(tt.query); got != tt.want {
+			t.Errorf("fuzzyTolerance(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyDistance(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		key         string
+		maxDistance int
+		want        int
+	}{
+		{"typo in full name", "alcie", "alice", 1, 1},
+		{"typo in prefix", "alcie", "alice_wonder", 1, 1},
+		{"capped above tolerance", "zzzzz", "alice", 1, 2},
+		{"shorter key", "alicee", "alice", 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fuzzyDistance(tt.query, tt.key, tt.maxDistance); got != tt.want {
+				t.Errorf("fuzzyDistance(%q, %q, %d) = %d, want %d", tt.query, tt.key, tt.maxDistance, got, tt.want)
+			}
+		})
+	}
+}
+
+func setUserIndex(t *testing.T, entries []UserSearchEntry) {
+	t.Helper()
+
+	userIndexMu.Lock()
+	previous := userIndex
+	userIndex = entries
+	userIndexMu.Unlock()
+
+	t.Cleanup(func() {
+		userIndexMu.Lock()
+		userIndex = previous
+		userIndexMu.Unlock()
+	})
+}
+
+func searchEntry(id int, username string, postCount int, lastActiveAt string) UserSearchEntry {
+	return UserSearchEntry{
+		ID:           id,
+		Username:     username,
+		PostCount:    postCount,
+		LastActiveAt: lastActiveAt,
+		key:          strings.ToLower(username),
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	setUserIndex(t, []UserSearchEntry{
+		searchEntry(1, "alice", 1, "2026-01-01 00:00:00"),
+		searchEntry(2, "alice_b", 5, "2026-01-01 00:00:00"),
+		searchEntry(3, "alice_c", 5, "2026-02-01 00:00:00"),
+		searchEntry(4, "alicia", 50, "2026-03-01 00:00:00"),
+		searchEntry(5, "bob", 10, "2026-01-01 00:00:00"),
+		searchEntry(6, "Bobby", 0, "2026-01-01 00:00:00"),
+	})
+
+	tests := []struct {
+		name    string
+		query   string
+		exclude map[int]bool
+		want    []int
+		types   []string
+	}{
+		{
+			name:  "exact before prefix before fuzzy",
+			query: "alice",
+			want:  []int{1, 3, 2, 4},
+			types: []string{MatchExact, MatchPrefix, MatchPrefix, MatchFuzzy},
+		},
+		{
+			name:  "case insensitive",
+			query: "ALICE",
+			want:  []int{1, 3, 2, 4},
+			types: []string{MatchExact, MatchPrefix, MatchPrefix, MatchFuzzy},
+		},
+		{
+			name:  "short queries skip fuzzy matching",
+			query: "bo",
+			want:  []int{5, 6},
+			types: []string{MatchPrefix, MatchPrefix},
+		},
+		{
+			name:  "transposed query matches fuzzily",
+			query: "bbo",
+			want:  []int{5, 6},
+			types: []string{MatchFuzzy, MatchFuzzy},
+		},
+		{
+			name:    "excluded users are skipped",
+			query:   "alice",
+			exclude: map[int]bool{1: true, 4: true},
+			want:    []int{3, 2},
+			types:   []string{MatchPrefix, MatchPrefix},
+		},
+		{
+			name:  "empty query lists everyone by activity",
+			query: "",
+			want:  []int{4, 5, 3, 2, 1, 6},
+		},
+		{
+			name:  "no match",
+			query: "zzzzzz",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := SearchUsers(tt.query, tt.exclude)
+
+			var got []int
+			for _, result := range results {
+				got = append(got, result.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("SearchUsers(%q) ids = %v, want %v", tt.query, got, tt.want)
+			}
+
+			for i, matchType := range tt.types {
+				if results[i].MatchType != matchType {
+					t.Errorf("SearchUsers(%q)[%d].MatchType = %q, want %q", tt.query, i, results[i].MatchType, matchType)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"camagru/globals"
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultTrashRetentionDays = 30
+
+var trashRetentionDays = defaultTrashRetentionDays
+
+func InitTrash() {
+	val := os.Getenv("TRASH_RETENTION_DAYS")
+	if val == "" {
+		return
+	}
+
+	days, err := strconv.Atoi(val)
+	if err != nil || days < 1 {
+		log.Fatalf("invalid TRASH_RETENTION_DAYS value %q", val)
+	}
+	trashRetentionDays = days
+}
+
+func TrashRetentionDays() int {
+	return trashRetentionDays
+}
+
+func StartTrashPurger(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := PurgeTrash()
+			if err != nil {
+				log.Printf("TrashPurger: %v", err)
+			} else if purged > 0 {
+				log.Printf("TrashPurger: purged %d posts", purged)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func PurgeTrash() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := "SELECT id, image_path FROM posts WHERE deleted_at IS NOT NULL AND deleted_at < DATE_SUB(NOW(), INTERVAL ? DAY)"
+	rows, err := globals.DB.QueryContext(ctx, query, trashRetentionDays)
+	if err != nil {
+		return 0, err
+	}
+
+	type expiredPost struct {
+		ID        int
+		ImagePath string
+	}
+
+	var expired []expiredPost
+	for rows.Next() {
+		var post expiredPost
+		if err := rows.Scan(&post.ID, &post.ImagePath); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		expired = append(expired, post)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, post := range expired {
+		imagePaths, err := postImagePaths(ctx, post.ID, post.ImagePath)
+		if err != nil {
+			return purged, err
+		}
+
+		result, err := globals.DB.ExecContext(ctx, "DELETE FROM posts WHERE id = ? AND deleted_at IS NOT NULL", post.ID)
+		if err != nil {
+			return purged, err
+		}
+
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			continue
+		}
+		purged++
+
+		for _, path := range imagePaths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("TrashPurger: remove %s: %v", path, err)
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+func postImagePaths(ctx context.Context, postID int, coverPath string) ([]string, error) {
+	rows, err := globals.DB.QueryContext(ctx, "SELECT image_path FROM post_media WHERE post_id = ?", postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paths := []string{coverPath}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		if path != coverPath {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, rows.Err()
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"camagru/globals"
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	TrendingWindowDays    = 7
+	trendingGravity       = 1.8
+	trendingCommentWeight = 2
+)
+
+func StartTrendingScorer(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if scored, err := RefreshTrendingScores(); err != nil {
+				log.Printf("TrendingScorer: %v", err)
+			} else if scored > 0 {
+				log.Printf("TrendingScorer: scored %d posts", scored)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func RefreshTrendingScores() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var computedAt string
+	if err := globals.DB.QueryRowContext(ctx, "SELECT NOW()").Scan(&computedAt); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO post_scores (post_id, like_count, comment_count, score, computed_at)
+		SELECT
+			p.id,
+			p.like_count,
+			p.comment_count,
+			(p.like_count + ? * p.comment_count)
+				/ POW(GREATEST(TIMESTAMPDIFF(MINUTE, p.created_at, ?), 0) / 60 + 2, ?),
+			?
+		FROM posts p
+		WHERE p.deleted_at IS NULL AND p.archived_at IS NULL AND p.is_published = TRUE
+			AND p.created_at >= DATE_SUB(?, INTERVAL ? DAY)
+		ON DUPLICATE KEY UPDATE
+			like_count = VALUES(like_count),
+			comment_count = VALUES(comment_count),
+			score = VALUES(score),
+			computed_at = VALUES(computed_at)
+	`
+	result, err := globals.DB.ExecContext(ctx, query, trendingCommentWeight, computedAt, trendingGravity, computedAt, computedAt, TrendingWindowDays)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := globals.DB.ExecContext(ctx, "DELETE FROM post_scores WHERE computed_at < ?", computedAt); err != nil {
+		return 0, err
+	}
+
+	scored, _ := result.RowsAffected()
+	return int(scored), nil
+}
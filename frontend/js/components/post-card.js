@@ -137,35 +137,52 @@ export const PostCard = {
         });
     },
 
+    renderComment(comment, currentUser) {
+        const isOwnComment = currentUser && currentUser.user_id === comment.user_id;
+        const replyCount = comment.reply_count || 0;
+
+        return `
+            <div class="comment-item" data-comment-id="${comment.id}">
+                <div class="comment-item__header">
+                    <a href="#/profile/${escapeHtml(comment.username)}" class="comment-item__username">${escapeHtml(comment.username)}</a>
+                    <span class="comment-item__time">${formatDate(comment.created_at)}</span>
+                    ${isOwnComment ? `
+                        <button class="comment-item__delete" data-comment-id="${comment.id}" aria-label="Delete comment">
+                            <svg width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2">
+                                <line x1="18" y1="6" x2="6" y2="18"></line>
+                                <line x1="6" y1="6" x2="18" y2="18"></line>
+                            </svg>
+                        </button>
+                    ` : ''}
+                </div>
+                <p class="comment-item__text">${escapeHtml(comment.comment)}</p>
+                ${replyCount > 0 ? `
+                    <button class="comment-item__toggle-replies" data-comment-id="${comment.id}">
+                        View ${replyCount} ${replyCount === 1 ? 'reply' : 'replies'}
+                    </button>
+                ` : ''}
+                <div class="comment-item__replies"></div>
+            </div>
+        `;
+    },
+
+    renderLoadMore(className, cursor) {
+        return cursor
+            ? `<button class="comment-list__more ${className}" data-cursor="${escapeHtml(cursor)}">Load more</button>`
+            : '';
+    },
+
     async showCommentsModal(postId) {
         const isGuest = !store.isAuthenticated();
 
         try {
             const response = await postService.getComments(postId);
             const comments = response.data?.comments || [];
+            const nextCursor = response.data?.pagination?.next_cursor;
             const currentUser = store.getUser();
 
             const commentsHtml = comments.length > 0
-                ? comments.map(c => {
-                    const isOwnComment = currentUser && currentUser.user_id === c.user_id;
-                    return `
-                        <div class="comment-item" data-comment-id="${c.id}">
-                            <div class="comment-item__header">
-                                <a href="#/profile/${escapeHtml(c.username)}" class="comment-item__username">${escapeHtml(c.username)}</a>
-                                <span class="comment-item__time">${formatDate(c.created_at)}</span>
-                                ${isOwnComment ? `
-                                    <button class="comment-item__delete" data-comment-id="${c.id}" aria-label="Delete comment">
-                                        <svg width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2">
-                                            <line x1="18" y1="6" x2="6" y2="18"></line>
-                                            <line x1="6" y1="6" x2="18" y2="18"></line>
-                                        </svg>
-                                    </button>
-                                ` : ''}
-                            </div>
-                            <p class="comment-item__text">${escapeHtml(c.comment)}</p>
-                        </div>
-                    `;
-                }).join('')
+                ? comments.map(c => this.renderComment(c, currentUser)).join('') + this.renderLoadMore('comment-list__more--comments', nextCursor)
                 : '<p class="text-muted text-center">No comments yet</p>';
 
             const content = `
@@ -185,6 +202,62 @@ export const PostCard = {
             `;
 
             const modal = Modal.show(content, { title: 'Comments', size: 'large' });
+            const commentList = modal.element.querySelector('#comment-list');
+
+            commentList.addEventListener('click', async (e) => {
+                const moreComments = e.target.closest('.comment-list__more--comments');
+                if (moreComments) {
+                    e.preventDefault();
+                    moreComments.disabled = true;
+                    try {
+                        const page = await postService.getComments(postId, { after: moreComments.dataset.cursor });
+                        const html = (page.data?.comments || []).map(c => this.renderComment(c, currentUser)).join('')
+                            + this.renderLoadMore('comment-list__more--comments', page.data?.pagination?.next_cursor);
+                        moreComments.insertAdjacentHTML('beforebegin', html);
+                        moreComments.remove();
+                    } catch (error) {
+                        moreComments.disabled = false;
+                        Modal.alert('Failed to load comments.');
+                    }
+                    return;
+                }
+
+                const toggleReplies = e.target.closest('.comment-item__toggle-replies, .comment-list__more--replies');
+                if (toggleReplies) {
+                    e.preventDefault();
+                    const item = toggleReplies.closest('.comment-item');
+                    const repliesContainer = item.querySelector(':scope > .comment-item__replies');
+                    const after = toggleReplies.dataset.cursor || '';
+                    toggleReplies.disabled = true;
+                    try {
+                        const page = await postService.getCommentReplies(item.dataset.commentId, after);
+                        const html = (page.data?.comments || []).map(c => this.renderComment(c, currentUser)).join('')
+                            + this.renderLoadMore('comment-list__more--replies', page.data?.pagination?.next_cursor);
+                        repliesContainer.insertAdjacentHTML('beforeend', html);
+                        toggleReplies.remove();
+                    } catch (error) {
+                        toggleReplies.disabled = false;
+                        Modal.alert('Failed to load replies.');
+                    }
+                    return;
+                }
+
+                const deleteBtn = e.target.closest('.comment-item__delete');
+                if (deleteBtn && !isGuest) {
+                    e.preventDefault();
+                    try {
+                        await postService.deleteComment(deleteBtn.dataset.commentId);
+                        const commentItem = deleteBtn.closest('.comment-item');
+                        if (commentItem) commentItem.remove();
+
+                        if (!commentList.querySelector('.comment-item')) {
+                            commentList.innerHTML = '<p class="text-muted text-center">No comments yet</p>';
+                        }
+                    } catch (error) {
+                        Modal.alert('Failed to delete comment.');
+                    }
+                }
+            });
 
             if (!isGuest) {
                 const form = modal.element.querySelector('#comment-form');
@@ -203,26 +276,6 @@ export const PostCard = {
                         }
                     }
                 });
-
-                modal.element.querySelectorAll('.comment-item__delete').forEach(btn => {
-                    btn.addEventListener('click', async (e) => {
-                        e.preventDefault();
-                        const commentId = btn.dataset.commentId;
-
-                        try {
-                            await postService.deleteComment(commentId);
-                            const commentItem = btn.closest('.comment-item');
-                            if (commentItem) commentItem.remove();
-
-                            const commentList = modal.element.querySelector('#comment-list');
-                            if (!commentList.querySelector('.comment-item')) {
-                                commentList.innerHTML = '<p class="text-muted text-center">No comments yet</p>';
-                            }
-                        } catch (error) {
-                            Modal.alert('Failed to delete comment.');
-                        }
-                    });
-                });
             }
 
         } catch (error) {
@@ -7,11 +7,15 @@ import { CONFIG } from '../config.js';
 export const homePage = {
     currentPage: 1,
     totalPages: 1,
+    nextCursor: null,
+    prevCursor: null,
     isLoading: false,
     posts: [],
 
     async init() {
         this.currentPage = 1;
+        this.nextCursor = null;
+        this.prevCursor = null;
         this.posts = [];
         this.render();
         await this.loadPosts();
@@ -57,15 +61,15 @@ export const homePage = {
         const nextBtn = $('#next-btn');
 
         if (prevBtn) {
-            prevBtn.addEventListener('click', () => this.goToPage(this.currentPage - 1));
+            prevBtn.addEventListener('click', () => this.goToPage(this.currentPage - 1, { before: this.prevCursor }));
         }
 
         if (nextBtn) {
-            nextBtn.addEventListener('click', () => this.goToPage(this.currentPage + 1));
+            nextBtn.addEventListener('click', () => this.goToPage(this.currentPage + 1, { after: this.nextCursor }));
         }
     },
 
-    async loadPosts() {
+    async loadPosts(cursor = {}) {
         if (this.isLoading) return;
 
         this.isLoading = true;
@@ -76,11 +80,14 @@ export const homePage = {
         }
 
         try {
-            const response = await postService.getFeed(this.currentPage, CONFIG.DEFAULT_PAGE_SIZE);
+            const response = await postService.getFeed(this.currentPage, CONFIG.DEFAULT_PAGE_SIZE, cursor);
 
             if (response.success && response.data) {
+                const pagination = response.data.pagination || {};
                 this.posts = response.data.posts || [];
-                this.totalPages = response.data.pagination?.total_pages || 1;
+                this.totalPages = Math.max(1, Math.ceil((pagination.total_count || 0) / CONFIG.DEFAULT_PAGE_SIZE));
+                this.nextCursor = pagination.next_cursor || null;
+                this.prevCursor = pagination.prev_cursor || null;
                 this.renderPosts();
             }
         } catch (error) {
@@ -142,22 +149,22 @@ export const homePage = {
         const prevBtn = $('#prev-btn');
         const nextBtn = $('#next-btn');
 
-        if (this.totalPages <= 1) {
+        if (!this.nextCursor && !this.prevCursor) {
             pagination.classList.add('hidden');
             return;
         }
 
         pagination.classList.remove('hidden');
         pageInfo.textContent = `Page ${this.currentPage} of ${this.totalPages}`;
-        prevBtn.disabled = this.currentPage <= 1;
-        nextBtn.disabled = this.currentPage >= this.totalPages;
+        prevBtn.disabled = !this.prevCursor;
+        nextBtn.disabled = !this.nextCursor;
     },
 
-    async goToPage(page) {
-        if (page < 1 || page > this.totalPages || this.isLoading) return;
+    async goToPage(page, cursor) {
+        if (!cursor.after && !cursor.before || this.isLoading) return;
 
         this.currentPage = page;
-        await this.loadPosts();
+        await this.loadPosts(cursor);
 
         window.scrollTo({ top: 0, behavior: 'smooth' });
     }
@@ -69,6 +69,13 @@ class ApiService {
         });
     }
 
+    put(endpoint, body) {
+        return this.request(endpoint, {
+            method: 'PUT',
+            body: JSON.stringify(body)
+        });
+    }
+
     patch(endpoint, body) {
         return this.request(endpoint, {
             method: 'PATCH',
@@ -0,0 +1,36 @@
+import { api } from './api.js';
+import { CONFIG } from '../config.js';
+
+export const challengeService = {
+    async getChallenges(status = '', page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
+        const params = new URLSearchParams({ page, limit });
+        if (status) params.set('status', status);
+        return api.get(`/api/challenges?${params.toString()}`);
+    },
+
+    async getChallenge(challengeId) {
+        return api.get(`/api/challenges/${challengeId}`);
+    },
+
+    async createChallenge({ title, description = '', stickers = [], startsAt, endsAt }) {
+        return api.post('/api/challenges', {
+            title,
+            description,
+            stickers,
+            starts_at: startsAt,
+            ends_at: endsAt
+        });
+    },
+
+    async getEntries(challengeId, page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE, sort = 'top') {
+        return api.get(`/api/challenges/${challengeId}/entries?page=${page}&limit=${limit}&sort=${sort}`);
+    },
+
+    async submitEntry(challengeId, postId) {
+        return api.post(`/api/challenges/${challengeId}/entries`, { post_id: postId });
+    },
+
+    async withdrawEntry(challengeId) {
+        return api.delete(`/api/challenges/${challengeId}/entries`);
+    }
+};
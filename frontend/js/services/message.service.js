@@ -0,0 +1,42 @@
+import { api } from './api.js';
+import { CONFIG } from '../config.js';
+
+export const messageService = {
+    async getConversations(page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
+        return api.get(`/api/conversations?page=${page}&limit=${limit}`);
+    },
+
+    async startConversation(username) {
+        return api.post('/api/conversations', { username });
+    },
+
+    async getMessages(conversationId, { limit, sort, after, before } = {}) {
+        const params = new URLSearchParams();
+        if (limit) params.set('limit', limit);
+        if (sort) params.set('sort', sort);
+        if (after) params.set('after', after);
+        if (before) params.set('before', before);
+        const query = params.toString();
+        return api.get(`/api/conversations/${conversationId}/messages${query ? `?${query}` : ''}`);
+    },
+
+    async pollMessages(conversationId, after) {
+        return this.getMessages(conversationId, { sort: 'oldest', after });
+    },
+
+    async sendMessage(conversationId, body, postId = null) {
+        return api.post(`/api/conversations/${conversationId}/messages`, { body, post_id: postId });
+    },
+
+    async sharePost(conversationId, postId, body = '') {
+        return this.sendMessage(conversationId, body, postId);
+    },
+
+    async markRead(conversationId) {
+        return api.post(`/api/conversations/${conversationId}/read`, {});
+    },
+
+    async getUnreadCount() {
+        return api.get('/api/me/unread-messages');
+    }
+};
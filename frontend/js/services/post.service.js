@@ -2,8 +2,32 @@ import { api } from './api.js';
 import { CONFIG } from '../config.js';
 
 export const postService = {
-    async getFeed(page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
-        return api.get(`/api/get/feed?page=${page}&limit=${limit}`);
+    async getFeed(page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE, { usernames, from, to, liked, sort, after, before } = {}) {
+        const params = new URLSearchParams({ page, limit });
+        if (after) params.set('after', after);
+        if (before) params.set('before', before);
+        if (usernames && usernames.length) params.set('usernames', usernames.join(','));
+        if (from) params.set('from', from);
+        if (to) params.set('to', to);
+        if (liked !== undefined) params.set('liked', liked);
+        if (sort) params.set('sort', sort);
+        return api.get(`/api/get/feed?${params.toString()}`);
+    },
+
+    async getTrendingFeed(page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
+        return this.getFeed(page, limit, { sort: 'trending' });
+    },
+
+    async repost(postId, commentary = '') {
+        return api.post(`/api/posts/${postId}/repost`, { commentary });
+    },
+
+    async unrepost(postId) {
+        return api.delete(`/api/posts/${postId}/repost`);
+    },
+
+    async getUserReposts(username, page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
+        return api.get(`/api/users/${encodeURIComponent(username)}/reposts?page=${page}&limit=${limit}`);
     },
 
     async getUserPosts() {
@@ -14,10 +38,11 @@ export const postService = {
         return api.get(`/api/get/user/${encodeURIComponent(username)}/posts`);
     },
 
-    async createPost(imageData, filterName = '') {
+    async createPost(imageData, filterName = '', publishAt = '') {
         return api.post('/api/create/post', {
             image: imageData,
-            filter: filterName
+            filter: filterName,
+            publish_at: publishAt
         });
     },
 
@@ -25,10 +50,90 @@ export const postService = {
         return api.delete(`/api/delete/post/${postId}`);
     },
 
+    async createCarouselPost(images, publishAt = '') {
+        return api.post('/api/create/post', {
+            images: images.map(({ imageData, filterName = '' }) => ({
+                image: imageData,
+                filter: filterName
+            })),
+            publish_at: publishAt
+        });
+    },
+
+    async savePost(postId, collectionId = null) {
+        return api.post(`/api/posts/${postId}/save`, { collection_id: collectionId });
+    },
+
+    async unsavePost(postId) {
+        return api.delete(`/api/posts/${postId}/save`);
+    },
+
+    async getSavedPosts(page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE, collectionId = null) {
+        const collection = collectionId ? `&collection_id=${collectionId}` : '';
+        return api.get(`/api/me/saved?page=${page}&limit=${limit}${collection}`);
+    },
+
+    async getCollections() {
+        return api.get('/api/me/collections');
+    },
+
+    async createCollection(name) {
+        return api.post('/api/me/collections', { name });
+    },
+
+    async deleteCollection(collectionId) {
+        return api.delete(`/api/me/collections/${collectionId}`);
+    },
+
+    async getTrash() {
+        return api.get('/api/trash');
+    },
+
+    async restorePost(postId) {
+        return api.post(`/api/posts/${postId}/restore`);
+    },
+
+    async archivePost(postId) {
+        return api.post(`/api/posts/${postId}/archive`);
+    },
+
+    async unarchivePost(postId) {
+        return api.delete(`/api/posts/${postId}/archive`);
+    },
+
+    async pinPost(postId) {
+        return api.post(`/api/posts/${postId}/pin`);
+    },
+
+    async unpinPost(postId) {
+        return api.delete(`/api/posts/${postId}/pin`);
+    },
+
     async likePost(postId) {
         return api.post(`/api/like/post/${postId}`);
     },
 
+    async like(postId) {
+        return api.put(`/api/posts/${postId}/like`);
+    },
+
+    async unlike(postId) {
+        return api.delete(`/api/posts/${postId}/like`);
+    },
+
+    async getPostLikes(postId, after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/posts/${postId}/likes${cursor}`);
+    },
+
+    async setReaction(postId, reaction) {
+        return api.put(`/api/posts/${postId}/reaction`, { reaction });
+    },
+
+    async removeReaction(postId) {
+        return api.delete(`/api/posts/${postId}/reaction`);
+    },
+
     async addComment(postId, comment) {
         return api.post('/api/comment/post', {
             postid: parseInt(postId),
@@ -36,8 +141,43 @@ export const postService = {
         });
     },
 
-    async getComments(postId) {
-        return api.get(`/api/get/post/comments/${postId}`);
+    async getComments(postId, { limit, sort, after, before } = {}) {
+        const params = new URLSearchParams();
+        if (limit) params.set('limit', limit);
+        if (sort) params.set('sort', sort);
+        if (after) params.set('after', after);
+        if (before) params.set('before', before);
+        const query = params.toString();
+        return api.get(`/api/get/post/comments/${postId}${query ? `?${query}` : ''}`);
+    },
+
+    async replyComment(commentId, comment) {
+        return api.post(`/api/comments/${commentId}/replies`, { comment });
+    },
+
+    async getCommentReplies(commentId, after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/comments/${commentId}/replies${cursor}`);
+    },
+
+    async editComment(commentId, comment) {
+        return api.patch(`/api/comments/${commentId}`, { comment });
+    },
+
+    async likeComment(commentId) {
+        return api.post(`/api/comments/${commentId}/like`);
+    },
+
+    async hideComment(commentId) {
+        return api.post(`/api/comments/${commentId}/hide`);
+    },
+
+    async unhideComment(commentId) {
+        return api.delete(`/api/comments/${commentId}/hide`);
+    },
+
+    async setCommentPolicy(postId, policy) {
+        return api.patch(`/api/posts/${postId}/comment-policy`, { policy });
     },
 
     async deleteComment(commentId) {
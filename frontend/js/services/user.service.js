@@ -1,5 +1,6 @@
 import { api } from './api.js';
 import { store } from '../state/store.js';
+import { CONFIG } from '../config.js';
 
 export const userService = {
     async getMe() {
@@ -54,5 +55,100 @@ export const userService = {
         }
 
         return response;
+    },
+
+    async follow(username) {
+        return api.post(`/api/users/${encodeURIComponent(username)}/follow`, {});
+    },
+
+    async unfollow(username) {
+        return api.delete(`/api/users/${encodeURIComponent(username)}/follow`);
+    },
+
+    async getFollowers(username, after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/users/${encodeURIComponent(username)}/followers${cursor}`);
+    },
+
+    async getFollowing(username, after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/users/${encodeURIComponent(username)}/following${cursor}`);
+    },
+
+    async setPrivacy(isPrivate) {
+        const response = await api.patch('/api/set/privacy', { is_private: isPrivate });
+
+        if (response.success) {
+            const currentUser = store.getUser();
+            store.setUser({ ...currentUser, is_private: isPrivate });
+        }
+
+        return response;
+    },
+
+    async requestAccess(username) {
+        return api.post(`/api/users/${encodeURIComponent(username)}/access-request`, {});
+    },
+
+    async cancelAccessRequest(username) {
+        return api.delete(`/api/users/${encodeURIComponent(username)}/access-request`);
+    },
+
+    async getAccessRequests(status = 'pending', after = '') {
+        const params = new URLSearchParams({ status });
+        if (after) params.set('after', after);
+        return api.get(`/api/me/access-requests?${params.toString()}`);
+    },
+
+    async approveAccessRequest(requestId) {
+        return api.post(`/api/me/access-requests/${requestId}/approve`, {});
+    },
+
+    async denyAccessRequest(requestId) {
+        return api.post(`/api/me/access-requests/${requestId}/deny`, {});
+    },
+
+    async revokeAccess(requestId) {
+        return api.delete(`/api/me/access-requests/${requestId}`);
+    },
+
+    async block(username) {
+        return api.post(`/api/users/${encodeURIComponent(username)}/block`, {});
+    },
+
+    async unblock(username) {
+        return api.delete(`/api/users/${encodeURIComponent(username)}/block`);
+    },
+
+    async mute(username) {
+        return api.post(`/api/users/${encodeURIComponent(username)}/mute`, {});
+    },
+
+    async unmute(username) {
+        return api.delete(`/api/users/${encodeURIComponent(username)}/mute`);
+    },
+
+    async getBlockedUsers(after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/me/blocked${cursor}`);
+    },
+
+    async getMutedUsers(after = '') {
+        const cursor = after ? `?after=${encodeURIComponent(after)}` : '';
+        return api.get(`/api/me/muted${cursor}`);
+    },
+
+    async searchUsers(query, page = 1, limit = CONFIG.DEFAULT_PAGE_SIZE) {
+        const params = new URLSearchParams({ q: query, page, limit });
+        return api.get(`/api/search/users?${params.toString()}`);
+    },
+
+    async getSuggestedUsers(limit = 10) {
+        return api.get(`/api/suggestions/users?limit=${limit}`);
+    },
+
+    async getMentionSuggestions(query, limit = 8) {
+        const params = new URLSearchParams({ q: query, limit });
+        return api.get(`/api/mentions/suggestions?${params.toString()}`);
     }
 };